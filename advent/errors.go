@@ -0,0 +1,34 @@
+package advent
+
+import "fmt"
+
+// Type ParseError describes a problem with a line of the puzzle input.
+//
+// Functions that parse a single line and don't know where it came from can
+// return a ParseError with only the Column and Token filled in; Input.Wrap
+// fills in the rest.
+type ParseError struct {
+	File   string // Name of the input file
+	Line   int    // Line number, starting at 1
+	Column int    // Column of the offending token, starting at 1 (0 if unknown)
+	Token  string // The offending part of the line
+	Err    error  // What's wrong with it
+}
+
+// Error formats the error like "day01/input.txt:1:5: "X5": invalid direction"
+func (e *ParseError) Error() string {
+	pos := fmt.Sprintf("%s:%d", e.File, e.Line)
+	if e.Column > 0 {
+		pos = fmt.Sprintf("%s:%d", pos, e.Column)
+	}
+
+	if e.Token != "" {
+		return fmt.Sprintf("%s: %q: %s", pos, e.Token, e.Err)
+	}
+	return fmt.Sprintf("%s: %s", pos, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
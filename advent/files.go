@@ -1,33 +1,14 @@
 package advent
 
-import (
-	"bufio"
-	"os"
-	"strings"
-)
+import "context"
 
 // ReadFile returns the lines of text in a given file, skipping blank lines.
 func ReadFile(filename string) ([]string, error) {
-	fh, err := os.Open(filename)
+	in, err := OpenInput(context.Background(), filename)
 	if err != nil {
 		return nil, err
 	}
+	defer in.Close()
 
-	var lines []string
-
-	scanner := bufio.NewScanner(fh)
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if len(line) == 0 {
-			continue
-		}
-		lines = append(lines, line)
-	}
-
-	err = scanner.Err()
-	if err != nil {
-		return nil, err
-	}
-
-	return lines, nil
+	return in.Lines()
 }
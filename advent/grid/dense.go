@@ -0,0 +1,183 @@
+package grid
+
+// Type Dense is a fixed size grid where every cell is stored.
+type Dense[T any] struct {
+	width  int
+	height int
+	cells  []T // Row by row
+}
+
+// NewDense creates a grid of the given size with every cell set to the zero
+// value.
+func NewDense[T any](width, height int) *Dense[T] {
+	return &Dense[T]{
+		width:  width,
+		height: height,
+		cells:  make([]T, width*height),
+	}
+}
+
+// FromRows creates a grid from a list of rows. The grid is as wide as the
+// longest row; shorter rows are padded with the zero value.
+func FromRows[T any](rows [][]T) *Dense[T] {
+	var width int
+	for _, row := range rows {
+		if len(row) > width {
+			width = len(row)
+		}
+	}
+
+	g := NewDense[T](width, len(rows))
+	for y, row := range rows {
+		copy(g.Row(y), row)
+	}
+	return g
+}
+
+// Width returns the width of the grid.
+func (g *Dense[T]) Width() int {
+	return g.width
+}
+
+// Height returns the height of the grid.
+func (g *Dense[T]) Height() int {
+	return g.height
+}
+
+// InBounds tells whether a point is on the grid.
+func (g *Dense[T]) InBounds(p Point) bool {
+	return p.X >= 0 && p.X < g.width && p.Y >= 0 && p.Y < g.height
+}
+
+// Bounds returns the corners of the grid.
+func (g *Dense[T]) Bounds() (Point, Point) {
+	return Point{0, 0}, Point{g.width - 1, g.height - 1}
+}
+
+// Get returns the value at a point, or the zero value if it's out of bounds.
+func (g *Dense[T]) Get(p Point) T {
+	if !g.InBounds(p) {
+		var zero T
+		return zero
+	}
+	return g.cells[p.Y*g.width+p.X]
+}
+
+// Set changes the value at a point.
+func (g *Dense[T]) Set(p Point, value T) error {
+	if !g.InBounds(p) {
+		return OutOfBoundsError{p}
+	}
+	g.cells[p.Y*g.width+p.X] = value
+	return nil
+}
+
+// Row returns the cells of a row. Changing the slice changes the grid. A row
+// that's out of bounds has no cells, so it's nil.
+func (g *Dense[T]) Row(y int) []T {
+	if y < 0 || y >= g.height {
+		return nil
+	}
+	return g.cells[y*g.width : (y+1)*g.width]
+}
+
+// Column returns a copy of the cells in a column. A column that's out of bounds
+// has no cells, so it's nil.
+func (g *Dense[T]) Column(x int) []T {
+	if x < 0 || x >= g.width {
+		return nil
+	}
+
+	result := make([]T, g.height)
+	for y := range result {
+		result[y] = g.cells[y*g.width+x]
+	}
+	return result
+}
+
+// Fill sets every cell in a rectangle, clipped to the grid.
+func (g *Dense[T]) Fill(min, max Point, value T) {
+	for y := min.Y; y <= max.Y; y++ {
+		for x := min.X; x <= max.X; x++ {
+			g.Set(Point{x, y}, value)
+		}
+	}
+}
+
+// Each calls a function for every cell of the grid, row by row.
+func (g *Dense[T]) Each(fn func(p Point, value T)) {
+	for i, value := range g.cells {
+		fn(Point{i % g.width, i / g.width}, value)
+	}
+}
+
+// Find returns the first point (row by row) whose value matches.
+func (g *Dense[T]) Find(match func(T) bool) (Point, bool) {
+	for i, value := range g.cells {
+		if match(value) {
+			return Point{i % g.width, i / g.width}, true
+		}
+	}
+	return Point{}, false
+}
+
+// Count returns the number of cells whose value matches.
+func (g *Dense[T]) Count(match func(T) bool) int {
+	var count int
+	for _, value := range g.cells {
+		if match(value) {
+			count++
+		}
+	}
+	return count
+}
+
+// RotateRow shifts a row to the right by n cells, wrapping around. A negative
+// n shifts to the left.
+func (g *Dense[T]) RotateRow(y, n int) error {
+	if y < 0 || y >= g.height {
+		return OutOfBoundsError{Point{0, y}}
+	}
+	rotate(g.Row(y), n)
+	return nil
+}
+
+// RotateColumn shifts a column down by n cells, wrapping around. A negative n
+// shifts it up.
+func (g *Dense[T]) RotateColumn(x, n int) error {
+	if x < 0 || x >= g.width {
+		return OutOfBoundsError{Point{x, 0}}
+	}
+
+	column := g.Column(x)
+	rotate(column, n)
+	for y, value := range column {
+		g.cells[y*g.width+x] = value
+	}
+	return nil
+}
+
+// rotate shifts a slice to the right by n with wrap-around, in place.
+func rotate[T any](cells []T, n int) {
+	if len(cells) == 0 {
+		return
+	}
+
+	n %= len(cells)
+	if n < 0 {
+		n += len(cells)
+	}
+
+	// Rotating right by n is the same as reversing the whole slice and then
+	// reversing the two halves on either side of n.
+	reverse(cells)
+	reverse(cells[:n])
+	reverse(cells[n:])
+}
+
+// reverse reverses a slice in place.
+func reverse[T any](cells []T) {
+	for i, j := 0, len(cells)-1; i < j; i, j = i+1, j-1 {
+		cells[i], cells[j] = cells[j], cells[i]
+	}
+}
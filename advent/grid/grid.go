@@ -0,0 +1,51 @@
+package grid
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// Type Grid is a two dimensional grid of values. Cells that were never set
+// hold the zero value of T.
+type Grid[T any] interface {
+	// Get returns the value at a point, or the zero value if the point is out
+	// of bounds.
+	Get(p Point) T
+
+	// Set changes the value at a point. It returns an error if the point is
+	// out of bounds.
+	Set(p Point, value T) error
+
+	// InBounds tells whether a point is on the grid.
+	InBounds(p Point) bool
+
+	// Bounds returns the top left and bottom right corners (inclusive) of
+	// the area that holds values. For an empty grid, max is up and left of
+	// min.
+	Bounds() (min, max Point)
+}
+
+// Type OutOfBoundsError is returned when setting a point that's off the grid.
+type OutOfBoundsError struct {
+	Point Point
+}
+
+func (e OutOfBoundsError) Error() string {
+	return fmt.Sprintf("Point %s is out of bounds", e.Point)
+}
+
+// Render draws the grid in ASCII art, one line per row, using a function
+// that picks the character for each value.
+func Render[T any](g Grid[T], char func(T) rune) string {
+	var buf bytes.Buffer
+	min, max := g.Bounds()
+	for y := min.Y; y <= max.Y; y++ {
+		if y > min.Y {
+			buf.WriteRune('\n')
+		}
+		for x := min.X; x <= max.X; x++ {
+			buf.WriteRune(char(g.Get(Point{x, y})))
+		}
+	}
+	return buf.String()
+}
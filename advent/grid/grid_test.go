@@ -0,0 +1,149 @@
+package grid
+
+import (
+	"reflect"
+	"testing"
+)
+
+func render(g Grid[bool]) string {
+	return Render(g, func(lit bool) rune {
+		if lit {
+			return '#'
+		}
+		return '.'
+	})
+}
+
+func TestManhattan(t *testing.T) {
+	tests := []struct {
+		a, b   Point
+		expect int
+	}{
+		{Pt(0, 0), Pt(0, 0), 0},
+		{Pt(0, 0), Pt(3, 4), 7},
+		{Pt(-2, 5), Pt(1, -1), 9},
+	}
+
+	for _, test := range tests {
+		result := test.a.Manhattan(test.b)
+		if result != test.expect {
+			t.Errorf("Distance assertion error for %s to %s: expected %d, got %d",
+				test.a, test.b, test.expect, result)
+		}
+	}
+}
+
+func TestNeighbors(t *testing.T) {
+	p := Pt(1, 1)
+
+	expected := []Point{Pt(1, 0), Pt(2, 1), Pt(1, 2), Pt(0, 1)}
+	if result := p.Neighbors(false); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Neighbors assertion error: expected %v, got %v", expected, result)
+	}
+
+	if result := p.Neighbors(true); len(result) != 8 {
+		t.Errorf("Diagonal neighbors assertion error: expected 8, got %v", result)
+	}
+}
+
+func TestDenseBounds(t *testing.T) {
+	g := NewDense[bool](3, 2)
+
+	tests := []struct {
+		p      Point
+		expect bool
+	}{
+		{Pt(0, 0), true},
+		{Pt(2, 1), true},
+		{Pt(3, 1), false},
+		{Pt(2, 2), false},
+		{Pt(-1, 0), false},
+	}
+
+	for _, test := range tests {
+		if result := g.InBounds(test.p); result != test.expect {
+			t.Errorf("InBounds assertion error for %s: expected %v, got %v", test.p, test.expect, result)
+		}
+
+		err := g.Set(test.p, true)
+		if (err == nil) != test.expect {
+			t.Errorf("Set assertion error for %s: got error %v", test.p, err)
+		}
+	}
+
+	// Rows and columns off the grid have no cells.
+	for _, y := range []int{-1, 2} {
+		if row := g.Row(y); row != nil {
+			t.Errorf("Row(%d) assertion error: expected nil, got %v", y, row)
+		}
+	}
+	for _, x := range []int{-1, 3} {
+		if column := g.Column(x); column != nil {
+			t.Errorf("Column(%d) assertion error: expected nil, got %v", x, column)
+		}
+	}
+}
+
+func TestDenseRotate(t *testing.T) {
+	// The example screen from 2016 day 8.
+	g := NewDense[bool](7, 3)
+	g.Fill(Pt(0, 0), Pt(2, 1), true)
+
+	steps := []struct {
+		rotate func() error
+		expect string
+	}{
+		{
+			func() error { return g.RotateColumn(1, 1) },
+			"#.#....\n###....\n.#.....",
+		},
+		{
+			func() error { return g.RotateRow(0, 4) },
+			"....#.#\n###....\n.#.....",
+		},
+		{
+			func() error { return g.RotateColumn(1, 1) },
+			".#..#.#\n#.#....\n.#.....",
+		},
+		{
+			func() error { return g.RotateRow(2, -8) },
+			".#..#.#\n#.#....\n#......",
+		},
+	}
+
+	for i, step := range steps {
+		if err := step.rotate(); err != nil {
+			t.Fatalf("Step %d: unexpected error: %s", i, err)
+		}
+		if result := render(g); result != step.expect {
+			t.Errorf("Step %d: output assertion error: expected\n%s\ngot\n%s", i, step.expect, result)
+		}
+	}
+
+	if err := g.RotateRow(3, 1); err == nil {
+		t.Errorf("Expected an error rotating a row that's out of bounds")
+	}
+}
+
+func TestSparse(t *testing.T) {
+	g := NewSparse[bool]()
+	g.Set(Pt(-1, 2), true)
+	g.Set(Pt(1, 0), true)
+
+	if !g.Has(Pt(1, 0)) || g.Has(Pt(0, 0)) {
+		t.Errorf("Has assertion error")
+	}
+	if g.Len() != 2 {
+		t.Errorf("Len assertion error: expected 2, got %d", g.Len())
+	}
+
+	expected := "..#\n...\n#.."
+	if result := render(g); result != expected {
+		t.Errorf("Output assertion error: expected\n%s\ngot\n%s", expected, result)
+	}
+
+	// An empty grid draws nothing.
+	if result := render(NewSparse[bool]()); result != "" {
+		t.Errorf("Output assertion error: expected nothing for an empty grid, got %q", result)
+	}
+}
@@ -0,0 +1,73 @@
+package grid
+
+import "fmt"
+
+// Type Point is an X,Y coordinate on a grid. Like on a screen, X increases to
+// the right and Y increases going down.
+type Point struct {
+	X int
+	Y int
+}
+
+// Pt is shorthand for Point{x, y}.
+func Pt(x, y int) Point {
+	return Point{x, y}
+}
+
+// Unit vectors for moving around the grid.
+var (
+	Up    = Point{0, -1}
+	Right = Point{1, 0}
+	Down  = Point{0, 1}
+	Left  = Point{-1, 0}
+)
+
+// Orthogonal are the directions to a point's four neighbors, clockwise from
+// the top.
+var Orthogonal = []Point{Up, Right, Down, Left}
+
+// Diagonal are the directions to a point's diagonal neighbors, clockwise
+// from the top right.
+var Diagonal = []Point{{1, -1}, {1, 1}, {-1, 1}, {-1, -1}}
+
+// String formats the point like "(x,y)"
+func (p Point) String() string {
+	return fmt.Sprintf("(%d,%d)", p.X, p.Y)
+}
+
+// Add returns the sum of two points, for moving a point along a vector.
+func (p Point) Add(q Point) Point {
+	return Point{p.X + q.X, p.Y + q.Y}
+}
+
+// Scale multiplies a point by a distance, for making a longer vector.
+func (p Point) Scale(n int) Point {
+	return Point{p.X * n, p.Y * n}
+}
+
+// Manhattan returns the taxicab distance between two points.
+func (p Point) Manhattan(q Point) int {
+	return abs(p.X-q.X) + abs(p.Y-q.Y)
+}
+
+// Neighbors returns the four points next to this one, and the four diagonal
+// points too if requested.
+func (p Point) Neighbors(diagonal bool) []Point {
+	result := []Point{}
+	for _, dir := range Orthogonal {
+		result = append(result, p.Add(dir))
+	}
+	if diagonal {
+		for _, dir := range Diagonal {
+			result = append(result, p.Add(dir))
+		}
+	}
+	return result
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
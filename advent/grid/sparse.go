@@ -0,0 +1,75 @@
+package grid
+
+// Type Sparse is an unbounded grid that only stores the cells that were set,
+// for when most of the grid is empty or its size isn't known ahead of time.
+type Sparse[T any] struct {
+	cells map[Point]T
+}
+
+// NewSparse creates an empty sparse grid.
+func NewSparse[T any]() *Sparse[T] {
+	return &Sparse[T]{
+		cells: map[Point]T{},
+	}
+}
+
+// InBounds is always true: a sparse grid goes on forever.
+func (g *Sparse[T]) InBounds(p Point) bool {
+	return true
+}
+
+// Bounds returns the corners of the smallest rectangle that holds every cell
+// that was set. When no cells are set, max is up and left of min so that the
+// rectangle has nothing in it.
+func (g *Sparse[T]) Bounds() (Point, Point) {
+	min, max := Pt(0, 0), Pt(-1, -1)
+	first := true
+	for p := range g.cells {
+		if first {
+			min, max = p, p
+			first = false
+			continue
+		}
+
+		if p.X < min.X {
+			min.X = p.X
+		}
+		if p.Y < min.Y {
+			min.Y = p.Y
+		}
+		if p.X > max.X {
+			max.X = p.X
+		}
+		if p.Y > max.Y {
+			max.Y = p.Y
+		}
+	}
+	return min, max
+}
+
+// Get returns the value at a point, or the zero value if it wasn't set.
+func (g *Sparse[T]) Get(p Point) T {
+	return g.cells[p]
+}
+
+// Has tells whether a point was set.
+func (g *Sparse[T]) Has(p Point) bool {
+	_, ok := g.cells[p]
+	return ok
+}
+
+// Set changes the value at a point.
+func (g *Sparse[T]) Set(p Point, value T) error {
+	g.cells[p] = value
+	return nil
+}
+
+// Delete removes the value at a point.
+func (g *Sparse[T]) Delete(p Point) {
+	delete(g.cells, p)
+}
+
+// Len returns the number of cells that were set.
+func (g *Sparse[T]) Len() int {
+	return len(g.cells)
+}
@@ -0,0 +1,199 @@
+package advent
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// MaxLineLength is the longest line of input that can be read.
+const MaxLineLength = 1024 * 1024
+
+// Type Input reads the lines of a puzzle input lazily, one at a time, in the
+// style of a bufio.Scanner:
+//
+//	for in.Scan() {
+//		line := in.Text()
+//	}
+//	if err := in.Err(); err != nil {
+//		...
+//	}
+//
+// Leading and trailing whitespace is trimmed from each line, and blank lines
+// are skipped unless KeepBlank is set. Scanning stops with the context's error
+// if the context is canceled.
+//
+// Parsers report bad lines through Skip. In Strict mode, every bad line is an
+// error; otherwise the line is skipped and its error is kept in Skipped.
+type Input struct {
+	Name      string  // File name of the input, or "-" for standard input
+	KeepBlank bool    // Don't skip over blank lines
+	Strict    bool    // Reject lines that can't be parsed instead of skipping
+	Skipped   []error // The lines that were skipped when not in strict mode
+
+	ctx     context.Context
+	scanner *bufio.Scanner
+	closer  io.Closer
+	text    string
+	indent  int // Leading whitespace trimmed from the line, for columns
+	line    int
+	err     error
+}
+
+// OpenInput opens an input file for reading. The file name "-" reads from
+// standard input instead.
+func OpenInput(ctx context.Context, filename string) (*Input, error) {
+	if filename == "-" {
+		return NewInput(ctx, filename, os.Stdin), nil
+	}
+
+	fh, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	in := NewInput(ctx, filename, fh)
+	in.closer = fh
+	return in, nil
+}
+
+// NewInput reads the input from an io.Reader. The name is used to identify
+// the input in error messages.
+func NewInput(ctx context.Context, name string, r io.Reader) *Input {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, MaxLineLength)
+
+	return &Input{
+		Name:    name,
+		ctx:     ctx,
+		scanner: scanner,
+	}
+}
+
+// Context returns the context that the input was opened with. Solvers with
+// long running loops should check it for cancellation.
+func (in *Input) Context() context.Context {
+	return in.ctx
+}
+
+// Scan advances to the next line of input, which is then available from
+// Text. It returns false when there are no more lines or an error occurred.
+func (in *Input) Scan() bool {
+	if in.err != nil {
+		return false
+	}
+
+	for {
+		if err := in.ctx.Err(); err != nil {
+			in.err = err
+			return false
+		}
+
+		if !in.scanner.Scan() {
+			in.err = in.scanner.Err()
+			return false
+		}
+		in.line++
+
+		raw := in.scanner.Text()
+		in.text = strings.TrimSpace(raw)
+		in.indent = len(raw) - len(strings.TrimLeftFunc(raw, unicode.IsSpace))
+		if len(in.text) == 0 && !in.KeepBlank {
+			continue
+		}
+		return true
+	}
+}
+
+// Text returns the current line of input.
+func (in *Input) Text() string {
+	return in.text
+}
+
+// Line returns the line number of the current line, starting at 1. Blank
+// lines are counted even when they're skipped.
+func (in *Input) Line() int {
+	return in.line
+}
+
+// Err returns the first error that was encountered while scanning.
+func (in *Input) Err() error {
+	return in.err
+}
+
+// Errorf returns a ParseError for the current line of input. The column is
+// counted from the start of Text(), beginning at 1, or is 0 when the whole
+// line is at fault.
+func (in *Input) Errorf(column int, token, tmpl string, a ...interface{}) error {
+	if column > 0 {
+		column += in.indent
+	}
+	return &ParseError{
+		File:   in.Name,
+		Line:   in.line,
+		Column: column,
+		Token:  token,
+		Err:    fmt.Errorf(tmpl, a...),
+	}
+}
+
+// Wrap turns an error about the current line of input into a ParseError. If
+// it's already a ParseError, its file and line number are filled in.
+func (in *Input) Wrap(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var perr *ParseError
+	if errors.As(err, &perr) {
+		if perr.File == "" {
+			perr.File = in.Name
+		}
+		if perr.Line == 0 {
+			perr.Line = in.line
+			if perr.Column > 0 {
+				perr.Column += in.indent
+			}
+		}
+		return perr
+	}
+
+	return &ParseError{
+		File: in.Name,
+		Line: in.line,
+		Err:  err,
+	}
+}
+
+// Skip reports a line of input that couldn't be parsed. In strict mode the
+// error is returned and the parser should give up. Otherwise the error is
+// added to Skipped and nil is returned, so the parser can move on.
+func (in *Input) Skip(err error) error {
+	if in.Strict {
+		return err
+	}
+	in.Skipped = append(in.Skipped, err)
+	return nil
+}
+
+// Lines reads all of the remaining lines of input.
+func (in *Input) Lines() ([]string, error) {
+	lines := []string{}
+	for in.Scan() {
+		lines = append(lines, in.Text())
+	}
+	return lines, in.Err()
+}
+
+// Close closes the underlying file, if the input was opened from one.
+func (in *Input) Close() error {
+	if in.closer != nil {
+		return in.closer.Close()
+	}
+	return nil
+}
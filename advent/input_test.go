@@ -0,0 +1,134 @@
+package advent
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testInput = "  first line  \n\nsecond line\r\n\n\nthird line"
+
+func TestInputSkipsBlankLines(t *testing.T) {
+	in := NewInput(context.Background(), "test", strings.NewReader(testInput))
+
+	var (
+		lines   []string
+		numbers []int
+	)
+	for in.Scan() {
+		lines = append(lines, in.Text())
+		numbers = append(numbers, in.Line())
+	}
+	if err := in.Err(); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	expected := []string{"first line", "second line", "third line"}
+	if !reflect.DeepEqual(lines, expected) {
+		t.Errorf("Lines assertion error: expected %q, got %q", expected, lines)
+	}
+	if !reflect.DeepEqual(numbers, []int{1, 3, 6}) {
+		t.Errorf("Line numbers assertion error: expected [1 3 6], got %v", numbers)
+	}
+}
+
+func TestInputKeepBlank(t *testing.T) {
+	in := NewInput(context.Background(), "test", strings.NewReader(testInput))
+	in.KeepBlank = true
+
+	lines, err := in.Lines()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	expected := []string{"first line", "", "second line", "", "", "third line"}
+	if !reflect.DeepEqual(lines, expected) {
+		t.Errorf("Lines assertion error: expected %q, got %q", expected, lines)
+	}
+}
+
+func TestInputCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	in := NewInput(ctx, "test", strings.NewReader(testInput))
+
+	if !in.Scan() {
+		t.Fatalf("Expected to scan the first line: %v", in.Err())
+	}
+
+	cancel()
+	if in.Scan() {
+		t.Errorf("Scanned %q after the context was canceled", in.Text())
+	}
+	if in.Err() != context.Canceled {
+		t.Errorf("Error assertion error: expected %v, got %v", context.Canceled, in.Err())
+	}
+}
+
+func TestOpenInput(t *testing.T) {
+	in, err := OpenInput(context.Background(), "input_test.go")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer in.Close()
+
+	if !in.Scan() || in.Text() != "package advent" {
+		t.Errorf(`Expected the first line to be "package advent", got "%s"`, in.Text())
+	}
+
+	if _, err = OpenInput(context.Background(), "does-not-exist.txt"); err == nil {
+		t.Error("Expected an error opening a file that doesn't exist")
+	}
+}
+
+func TestInputErrors(t *testing.T) {
+	in := NewInput(context.Background(), "test.txt", strings.NewReader("R1\n\n  R2, X3\n"))
+	in.Scan()
+	in.Scan()
+
+	// Columns count from the start of the untrimmed line.
+	err := in.Errorf(5, "X3", "Invalid direction")
+	expected := `test.txt:3:7: "X3": Invalid direction`
+	if err.Error() != expected {
+		t.Errorf(`Errorf assertion error: expected "%s", got "%s"`, expected, err)
+	}
+
+	// Wrapping a ParseError that only knows its column.
+	err = in.Wrap(&ParseError{Column: 1, Token: "R2", Err: errors.New("Oops")})
+	expected = `test.txt:3:3: "R2": Oops`
+	if err.Error() != expected {
+		t.Errorf(`Wrap assertion error: expected "%s", got "%s"`, expected, err)
+	}
+
+	// Wrapping any other error.
+	cause := errors.New("Something else")
+	err = in.Wrap(cause)
+	expected = `test.txt:3: Something else`
+	if err.Error() != expected {
+		t.Errorf(`Wrap assertion error: expected "%s", got "%s"`, expected, err)
+	}
+	if !errors.Is(err, cause) {
+		t.Errorf("Expected the wrapped error to unwrap to its cause")
+	}
+}
+
+func TestInputSkip(t *testing.T) {
+	in := NewInput(context.Background(), "test.txt", strings.NewReader(testInput))
+	err := errors.New("Bad line")
+
+	if in.Skip(err) != nil {
+		t.Error("Expected Skip to return nil when not in strict mode")
+	}
+	if len(in.Skipped) != 1 {
+		t.Errorf("Expected 1 skipped line, got %d", len(in.Skipped))
+	}
+
+	in.Strict = true
+	if in.Skip(err) != err {
+		t.Error("Expected Skip to return the error in strict mode")
+	}
+	if len(in.Skipped) != 1 {
+		t.Errorf("Expected 1 skipped line, got %d", len(in.Skipped))
+	}
+}
@@ -0,0 +1,246 @@
+package advent
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Type Level is how verbose a log message is.
+type Level int
+
+// Log levels, from the most verbose to the least.
+const (
+	LevelTrace Level = iota
+	LevelDebug
+	LevelInfo
+	LevelOff
+)
+
+var levelNames = map[Level]string{
+	LevelTrace: "trace",
+	LevelDebug: "debug",
+	LevelInfo:  "info",
+	LevelOff:   "off",
+}
+
+// String returns the name of the level.
+func (l Level) String() string {
+	return levelNames[l]
+}
+
+// ParseLevel turns a level's name into a Level.
+func ParseLevel(name string) (Level, error) {
+	for level, n := range levelNames {
+		if n == name {
+			return level, nil
+		}
+	}
+	return LevelOff, fmt.Errorf("unknown log level: %s", name)
+}
+
+// Type Logger writes log messages for one component of the program. Component
+// names are dotted paths like "day10.bot", and the level for a component can
+// be configured on its own or for any of its parents (like "day10").
+//
+// Log messages are written to stderr so they don't mix with the answers.
+type Logger struct {
+	Name string
+}
+
+// NewLogger creates a logger for a component.
+func NewLogger(name string) *Logger {
+	return &Logger{Name: name}
+}
+
+// The logging configuration, shared by every Logger.
+var logConfig struct {
+	sync.Mutex
+	loaded   bool
+	fallback Level            // Level for components not in the map
+	levels   map[string]Level // Levels by component name
+	out      io.Writer        // Text output
+	json     io.Writer        // Optional JSON-lines output
+}
+
+func init() {
+	logConfig.out = os.Stderr
+}
+
+// ConfigureLogging sets the log levels from a spec like
+// "info,day10=debug,day10.bot=trace". Entries without a component name set
+// the level for everything else.
+//
+// If logging isn't configured, the spec comes from the environment variable
+// `$ADVENT_LOG`. If that isn't set, but `$DEBUG` is, everything is logged at
+// the debug level.
+func ConfigureLogging(spec string) error {
+	fallback, levels, err := parseLogSpec(spec)
+	if err != nil {
+		return err
+	}
+
+	logConfig.Lock()
+	defer logConfig.Unlock()
+	logConfig.loaded = true
+	logConfig.fallback = fallback
+	logConfig.levels = levels
+	return nil
+}
+
+// LogSpec returns the log levels as a spec for ConfigureLogging, so they can be
+// put back after changing them.
+func LogSpec() string {
+	logConfig.Lock()
+	defer logConfig.Unlock()
+	loadLogConfig()
+
+	entries := []string{logConfig.fallback.String()}
+	for name, level := range logConfig.levels {
+		entries = append(entries, name+"="+level.String())
+	}
+	sort.Strings(entries[1:])
+	return strings.Join(entries, ",")
+}
+
+// parseLogSpec parses the spec for ConfigureLogging.
+func parseLogSpec(spec string) (Level, map[string]Level, error) {
+	fallback := LevelInfo
+	levels := map[string]Level{}
+
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if len(entry) == 0 {
+			continue
+		}
+
+		name, levelName := "", entry
+		if i := strings.Index(entry, "="); i > -1 {
+			name, levelName = entry[:i], entry[i+1:]
+		}
+
+		level, err := ParseLevel(levelName)
+		if err != nil {
+			return fallback, nil, err
+		}
+
+		if name == "" {
+			fallback = level
+		} else {
+			levels[name] = level
+		}
+	}
+
+	return fallback, levels, nil
+}
+
+// SetLogJSON sends a copy of every log message to a writer as JSON, one
+// object per line. Pass nil to turn it off.
+func SetLogJSON(w io.Writer) {
+	logConfig.Lock()
+	defer logConfig.Unlock()
+	logConfig.json = w
+}
+
+// SetLogOutput changes where the text log messages are written.
+func SetLogOutput(w io.Writer) {
+	logConfig.Lock()
+	defer logConfig.Unlock()
+	logConfig.out = w
+}
+
+// loadLogConfig configures logging from the environment the first time it's
+// needed. The caller must hold the lock.
+func loadLogConfig() {
+	if logConfig.loaded {
+		return
+	}
+	logConfig.loaded = true
+
+	spec := os.Getenv("ADVENT_LOG")
+	if spec == "" && os.Getenv("DEBUG") != "" {
+		spec = "debug"
+	}
+
+	fallback, levels, err := parseLogSpec(spec)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "$ADVENT_LOG: %s\n", err)
+		fallback, levels = LevelInfo, map[string]Level{}
+	}
+	logConfig.fallback = fallback
+	logConfig.levels = levels
+}
+
+// level finds the level for a component, checking its parents too. The
+// caller must hold the lock.
+func (l *Logger) level() Level {
+	loadLogConfig()
+
+	name := l.Name
+	for {
+		if level, ok := logConfig.levels[name]; ok {
+			return level
+		}
+
+		i := strings.LastIndex(name, ".")
+		if i == -1 {
+			return logConfig.fallback
+		}
+		name = name[:i]
+	}
+}
+
+// Named returns a logger for a sub-component of this one.
+func (l *Logger) Named(name string) *Logger {
+	return NewLogger(l.Name + "." + name)
+}
+
+// Enabled tells whether messages at a level will be logged.
+func (l *Logger) Enabled(level Level) bool {
+	logConfig.Lock()
+	defer logConfig.Unlock()
+	return level >= l.level() && level < LevelOff
+}
+
+// Trace logs a message at the trace level.
+func (l *Logger) Trace(tmpl string, a ...interface{}) {
+	l.Log(LevelTrace, tmpl, a...)
+}
+
+// Debug logs a message at the debug level.
+func (l *Logger) Debug(tmpl string, a ...interface{}) {
+	l.Log(LevelDebug, tmpl, a...)
+}
+
+// Info logs a message at the info level.
+func (l *Logger) Info(tmpl string, a ...interface{}) {
+	l.Log(LevelInfo, tmpl, a...)
+}
+
+// Log writes a message if the level is enabled for this component.
+func (l *Logger) Log(level Level, tmpl string, a ...interface{}) {
+	logConfig.Lock()
+	defer logConfig.Unlock()
+
+	if level < l.level() || level >= LevelOff {
+		return
+	}
+
+	message := strings.TrimRight(fmt.Sprintf(tmpl, a...), "\n")
+	fmt.Fprintf(logConfig.out, "[%s] %s: %s\n", level, l.Name, message)
+
+	if logConfig.json != nil {
+		line, _ := json.Marshal(map[string]interface{}{
+			"time":      time.Now().Format(time.RFC3339Nano),
+			"level":     level.String(),
+			"component": l.Name,
+			"message":   message,
+		})
+		logConfig.json.Write(append(line, '\n'))
+	}
+}
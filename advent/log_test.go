@@ -0,0 +1,87 @@
+package advent
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestLogLevels(t *testing.T) {
+	var buf bytes.Buffer
+	SetLogOutput(&buf)
+	defer SetLogOutput(os.Stderr)
+	defer ConfigureLogging(LogSpec())
+
+	const spec = "info,day05=off,day10.bot=trace,day10=debug"
+	if err := ConfigureLogging(spec); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if result := LogSpec(); result != spec {
+		t.Errorf("LogSpec assertion error: expected %q, got %q", spec, result)
+	}
+
+	tests := []struct {
+		Component string
+		Level     Level
+		Enabled   bool
+	}{
+		{"day01", LevelInfo, true},
+		{"day01", LevelDebug, false},
+		{"day10", LevelDebug, true},
+		{"day10", LevelTrace, false},
+		{"day10.tick", LevelDebug, true},
+		{"day10.tick", LevelTrace, false},
+		{"day10.bot", LevelTrace, true},
+		{"day10.bot.hands", LevelTrace, true},
+		{"day100", LevelDebug, false},
+		{"day05", LevelInfo, false},
+	}
+	for _, test := range tests {
+		logger := NewLogger(test.Component)
+		if logger.Enabled(test.Level) != test.Enabled {
+			t.Errorf("%s at level %s: expected enabled=%v", test.Component, test.Level, test.Enabled)
+		}
+
+		buf.Reset()
+		logger.Log(test.Level, "Hello %s\n", "world")
+		expected := ""
+		if test.Enabled {
+			expected = "[" + test.Level.String() + "] " + test.Component + ": Hello world\n"
+		}
+		if buf.String() != expected {
+			t.Errorf(`Output assertion error: expected "%s", got "%s"`, expected, buf.String())
+		}
+	}
+
+	if err := ConfigureLogging("day10=loud"); err == nil {
+		t.Error("Expected an error for an unknown log level")
+	}
+}
+
+func TestLogJSON(t *testing.T) {
+	var text, buf bytes.Buffer
+	SetLogOutput(&text)
+	defer SetLogOutput(os.Stderr)
+	SetLogJSON(&buf)
+	defer SetLogJSON(nil)
+	ConfigureLogging("debug")
+
+	logger := NewLogger("day10").Named("bot")
+	logger.Debug("Bot %d gave a chip", 1)
+	logger.Trace("Not logged")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("Expected 1 line of JSON, got %d: %s", len(lines), buf.String())
+	}
+
+	var entry map[string]string
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if entry["level"] != "debug" || entry["component"] != "day10.bot" || entry["message"] != "Bot 1 gave a chip" {
+		t.Errorf("Unexpected JSON log entry: %v", entry)
+	}
+}
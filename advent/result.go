@@ -0,0 +1,105 @@
+package advent
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Result is the answer to one part of a day's puzzle.
+type Result struct {
+	Day         int           `json:"day"`
+	Part        int           `json:"part"`
+	Answer      interface{}   `json:"answer"`
+	Elapsed     time.Duration `json:"elapsed_ns"`
+	Diagnostics []string      `json:"diagnostics,omitempty"`
+}
+
+// Output formats for RenderResults.
+const (
+	FormatText  = "text"
+	FormatJSON  = "json"
+	FormatTable = "table"
+)
+
+// Answer is a convenience function for solvers to return a Result that only
+// has an answer.
+func Answer(answer interface{}) (Result, error) {
+	return Result{Answer: answer}, nil
+}
+
+// Diagnose adds a line of diagnostic output to the result.
+func (r *Result) Diagnose(tmpl string, a ...interface{}) {
+	r.Diagnostics = append(r.Diagnostics, fmt.Sprintf(tmpl, a...))
+}
+
+// String returns the answer as a string, which is how answers are compared
+// against their expected values.
+func (r Result) String() string {
+	return fmt.Sprint(r.Answer)
+}
+
+// Solve runs one part of a day's puzzle and times how long it took.
+func Solve(day, part int, in *Input) (Result, error) {
+	solver, err := Lookup(day)
+	if err != nil {
+		return Result{}, err
+	}
+
+	start := time.Now()
+	result, err := solver.Solve(part, in)
+	if err != nil {
+		return result, err
+	}
+
+	result.Day = day
+	result.Part = part
+	result.Elapsed = time.Since(start)
+
+	// Let the user know about any lines of input that were skipped.
+	for _, err := range in.Skipped {
+		result.Diagnose("Skipped: %s", err)
+	}
+	return result, nil
+}
+
+// RenderResults writes the results in one of the output formats.
+func RenderResults(w io.Writer, format string, results []Result) error {
+	switch format {
+	case FormatText:
+		for _, r := range results {
+			// Multi-line answers (like ASCII art) go below the heading.
+			answer := r.String()
+			if strings.Contains(answer, "\n") {
+				fmt.Fprintf(w, "Day %d, Part %d (%s):\n%s\n", r.Day, r.Part, r.Elapsed, answer)
+			} else {
+				fmt.Fprintf(w, "Day %d, Part %d: %s (%s)\n", r.Day, r.Part, answer, r.Elapsed)
+			}
+			for _, line := range r.Diagnostics {
+				fmt.Fprintf(w, "    %s\n", line)
+			}
+		}
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(results)
+	case FormatTable:
+		tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+		fmt.Fprintln(tw, "DAY\tPART\tANSWER\tELAPSED")
+		for _, r := range results {
+			// Multi-line answers don't fit in a table cell.
+			answer := r.String()
+			if i := strings.Index(answer, "\n"); i > -1 {
+				answer = answer[:i] + "..."
+			}
+			fmt.Fprintf(tw, "%d\t%d\t%s\t%s\n", r.Day, r.Part, answer, r.Elapsed)
+		}
+		return tw.Flush()
+	default:
+		return fmt.Errorf("unknown output format: %s", format)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package advent
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// Solver is implemented by each day's puzzle solution so that the `advent`
+// command can run any of them.
+type Solver interface {
+	// Solve runs one part (1 or 2) of the puzzle against the input and
+	// returns its answer. The Day, Part and Elapsed fields of the result are
+	// filled in by the caller.
+	Solve(part int, in *Input) (Result, error)
+}
+
+// SolverFunc adapts an ordinary function into a Solver.
+type SolverFunc func(part int, in *Input) (Result, error)
+
+// Solve calls f(part, in).
+func (f SolverFunc) Solve(part int, in *Input) (Result, error) {
+	return f(part, in)
+}
+
+// Flagger is implemented by a Solver that has command line options of its
+// own. The runner calls Flags for every registered Solver before parsing the
+// command line, so the flag names must be unique across all the days.
+type Flagger interface {
+	Flags(fs *flag.FlagSet)
+}
+
+// Exporter is implemented by a Solver that can draw its puzzle, for the
+// `advent export` command.
+type Exporter interface {
+	// ExportFormats lists the formats the Solver can export to.
+	ExportFormats() []string
+
+	// Export solves the puzzle and writes it to w in one of the formats.
+	Export(w io.Writer, format string, in *Input) error
+}
+
+// ErrNotImplemented is returned by a Solver for a part of the puzzle that
+// hasn't been solved.
+var ErrNotImplemented = errors.New("this part of the puzzle is not implemented")
+
+// The registered solvers, by day number.
+var solvers = map[int]Solver{}
+
+// Register makes a day's Solver available to the runner. It's meant to be
+// called from the init() function of each day's package.
+func Register(day int, s Solver) {
+	if _, ok := solvers[day]; ok {
+		panic(fmt.Sprintf("advent: Register called twice for day %d", day))
+	}
+	solvers[day] = s
+}
+
+// Lookup finds the Solver for a given day.
+func Lookup(day int) (Solver, error) {
+	s, ok := solvers[day]
+	if !ok {
+		return nil, fmt.Errorf("no solver is registered for day %d", day)
+	}
+	return s, nil
+}
+
+// Days returns the sorted list of days that have a registered Solver.
+func Days() []int {
+	days := []int{}
+	for day := range solvers {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+	return days
+}
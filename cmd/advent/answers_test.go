@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/kirsle/goadvent2016/advent"
+)
+
+// The root of the repo, relative to this package.
+const repoRoot = "../.."
+
+// Type Expected holds the expected answers for an input file. They're read
+// from a sidecar file next to the input, so `day01/test1.txt` has its answers
+// in `day01/test1.expected` that look like:
+//
+//	# Comments and blank lines are ignored.
+//	part1: 5
+//	part2: 4
+//
+// Parts that aren't listed in the file aren't checked. Keys that start with
+// "--" set the day's own command line options, like "--size: 7x3".
+type Expected struct {
+	Day     int               // The day of the puzzle
+	Input   string            // Path to the input file
+	Answers map[int]string    // Expected answers by part number
+	Flags   map[string]string // Options for the day's Solver
+}
+
+// TestAnswers runs every day's solver against its input files and checks
+// that the answers haven't drifted.
+func TestAnswers(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join(repoRoot, "day*", "*.expected"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("No .expected files were found")
+	}
+
+	for _, file := range files {
+		expected, err := ReadExpected(file)
+		if err != nil {
+			t.Errorf("%s: %s", file, err)
+			continue
+		}
+
+		for part := 1; part <= 2; part++ {
+			answer, ok := expected.Answers[part]
+			if !ok {
+				continue
+			}
+
+			name, _ := filepath.Rel(repoRoot, expected.Input)
+			name = fmt.Sprintf("%s/part%d", filepath.ToSlash(name), part)
+			t.Run(name, func(t *testing.T) {
+				in, err := advent.OpenInput(context.Background(), expected.Input)
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer in.Close()
+				in.Strict = true
+
+				if err := setFlags(expected.Flags); err != nil {
+					t.Fatal(err)
+				}
+
+				result, err := advent.Solve(expected.Day, part, in)
+				if err != nil {
+					t.Fatalf("Unexpected error: %s", err)
+				}
+				if result.String() != answer {
+					t.Errorf(`Answer assertion error: expected "%s", got "%s"`, answer, result.String())
+				}
+			})
+		}
+	}
+}
+
+// setFlags sets the options of the days' Solvers. Every other option is set
+// back to its default, so one test can't leak options into the next.
+func setFlags(flags map[string]string) error {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	dayFlags(fs)
+	for name, value := range flags {
+		if err := fs.Set(name, value); err != nil {
+			return fmt.Errorf("--%s: %s", name, err)
+		}
+	}
+	return nil
+}
+
+// ReadExpected parses an .expected file.
+func ReadExpected(file string) (Expected, error) {
+	expected := Expected{
+		Input:   strings.TrimSuffix(file, ".expected") + ".txt",
+		Answers: map[int]string{},
+		Flags:   map[string]string{},
+	}
+
+	// The day comes from the directory name, e.g. "day01".
+	dir := filepath.Base(filepath.Dir(file))
+	day, err := strconv.Atoi(strings.TrimPrefix(dir, "day"))
+	if err != nil {
+		return expected, fmt.Errorf("Can't get the day number from directory %s", dir)
+	}
+	expected.Day = day
+
+	if _, err := os.Stat(expected.Input); err != nil {
+		return expected, err
+	}
+
+	fh, err := os.Open(file)
+	if err != nil {
+		return expected, err
+	}
+	defer fh.Close()
+
+	scanner := bufio.NewScanner(fh)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			return expected, fmt.Errorf("Invalid line: %s", line)
+		}
+		key, value := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+
+		if strings.HasPrefix(key, "--") {
+			expected.Flags[strings.TrimPrefix(key, "--")] = value
+			continue
+		}
+
+		switch key {
+		case "part1":
+			expected.Answers[1] = value
+		case "part2":
+			expected.Answers[2] = value
+		default:
+			return expected, fmt.Errorf("Unknown key: %s", key)
+		}
+	}
+
+	return expected, scanner.Err()
+}
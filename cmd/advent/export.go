@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/kirsle/goadvent2016/advent"
+)
+
+// Export handles the `advent export` command.
+func Export(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	var (
+		day    = fs.Int("day", 0, "Day of the puzzle to export (required)")
+		input  = fs.String("input", "", "Input file, or - for stdin (default dayNN/input.txt)")
+		format = fs.String("format", "", "Export format (default the day's first format)")
+		output = fs.String("o", "-", "Output file, or - for stdout")
+		strict = fs.Bool("strict", false, "Fail on input lines that can't be parsed instead of skipping them")
+	)
+	dayFlags(fs)
+	fs.Parse(args)
+
+	solver, err := advent.Lookup(*day)
+	if err != nil {
+		return err
+	}
+	exporter, ok := solver.(advent.Exporter)
+	if !ok {
+		return fmt.Errorf("day %d can't be exported", *day)
+	}
+
+	formats := exporter.ExportFormats()
+	if *format == "" {
+		*format = formats[0]
+	}
+	known := false
+	for _, f := range formats {
+		known = known || f == *format
+	}
+	if !known {
+		return fmt.Errorf("day %d can't be exported as %s: use one of %s", *day, *format, strings.Join(formats, ", "))
+	}
+
+	if *input == "" {
+		*input = fmt.Sprintf("day%02d/input.txt", *day)
+	}
+
+	// Interrupting the program cancels the export.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	in, err := advent.OpenInput(ctx, *input)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	in.Strict = *strict
+
+	var w io.Writer = os.Stdout
+	if *output != "-" {
+		fh, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer fh.Close()
+		w = fh
+	}
+
+	return exporter.Export(w, *format, in)
+}
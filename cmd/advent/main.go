@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/signal"
+
+	"github.com/kirsle/goadvent2016/advent"
+
+	// Register each day's Solver.
+	_ "github.com/kirsle/goadvent2016/day01"
+	_ "github.com/kirsle/goadvent2016/day02"
+	_ "github.com/kirsle/goadvent2016/day03"
+	_ "github.com/kirsle/goadvent2016/day04"
+	_ "github.com/kirsle/goadvent2016/day05"
+	_ "github.com/kirsle/goadvent2016/day06"
+	_ "github.com/kirsle/goadvent2016/day07"
+	_ "github.com/kirsle/goadvent2016/day08"
+	_ "github.com/kirsle/goadvent2016/day09"
+	_ "github.com/kirsle/goadvent2016/day10"
+)
+
+const usage = `Usage: advent <command> [options]
+
+Commands:
+  run     Run the solver for a day of the puzzle.
+  export  Draw a day's puzzle in another format, like a graph or image.
+  list    List the days that have a solver.
+
+Run "advent <command> -h" for the options of a command.
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Print(usage)
+		os.Exit(1)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "run":
+		err = Run(os.Args[2:])
+	case "export":
+		err = Export(os.Args[2:])
+	case "list":
+		for _, day := range advent.Days() {
+			fmt.Printf("Day %d\n", day)
+		}
+	default:
+		fmt.Print(usage)
+		os.Exit(1)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+// dayFlags adds the options of the days that have their own.
+func dayFlags(fs *flag.FlagSet) {
+	for _, d := range advent.Days() {
+		s, _ := advent.Lookup(d)
+		if f, ok := s.(advent.Flagger); ok {
+			f.Flags(fs)
+		}
+	}
+}
+
+// Run handles the `advent run` command.
+func Run(args []string) error {
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	var (
+		day    = fs.Int("day", 0, "Day of the puzzle to solve (required)")
+		part   = fs.Int("part", 0, "Part of the puzzle to solve: 1 or 2 (default both)")
+		input  = fs.String("input", "", "Input file, or - for stdin (default dayNN/input.txt)")
+		format = fs.String("format", advent.FormatText, "Output format: text, json or table")
+		strict = fs.Bool("strict", false, "Fail on input lines that can't be parsed instead of skipping them")
+		logs   = fs.String("log", "", "Log levels, like \"info,day10=debug,day10.bot=trace\" (default $ADVENT_LOG)")
+		logTo  = fs.String("log-json", "", "Also write the logs to this file as JSON lines")
+	)
+	dayFlags(fs)
+	fs.Parse(args)
+
+	if *logs != "" {
+		if err := advent.ConfigureLogging(*logs); err != nil {
+			return err
+		}
+	}
+	if *logTo != "" {
+		fh, err := os.Create(*logTo)
+		if err != nil {
+			return err
+		}
+		defer fh.Close()
+		advent.SetLogJSON(fh)
+	}
+
+	if _, err := advent.Lookup(*day); err != nil {
+		return err
+	}
+
+	if *input == "" {
+		*input = fmt.Sprintf("day%02d/input.txt", *day)
+	}
+
+	// Which parts to run?
+	var parts []int
+	switch *part {
+	case 0:
+		parts = []int{1, 2}
+	case 1, 2:
+		parts = []int{*part}
+	default:
+		return fmt.Errorf("invalid part %d: must be 1 or 2", *part)
+	}
+
+	// Standard input can only be read once, so hold on to it for each part.
+	var stdin []byte
+	if *input == "-" && len(parts) > 1 {
+		var err error
+		stdin, err = ioutil.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Interrupting the program cancels the solver.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	results := []advent.Result{}
+	for _, p := range parts {
+		var in *advent.Input
+		if stdin != nil {
+			in = advent.NewInput(ctx, *input, bytes.NewReader(stdin))
+		} else {
+			var err error
+			in, err = advent.OpenInput(ctx, *input)
+			if err != nil {
+				return err
+			}
+		}
+
+		in.Strict = *strict
+		result, err := advent.Solve(*day, p, in)
+		in.Close()
+		if err == advent.ErrNotImplemented && len(parts) > 1 {
+			fmt.Fprintf(os.Stderr, "Day %d, Part %d: %s\n", *day, p, err)
+			continue
+		} else if err != nil {
+			return err
+		}
+		results = append(results, result)
+	}
+
+	return advent.RenderResults(os.Stdout, *format, results)
+}
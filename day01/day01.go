@@ -0,0 +1,197 @@
+package day01
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/kirsle/goadvent2016/advent"
+	"github.com/kirsle/goadvent2016/advent/grid"
+)
+
+// For debug output: export ADVENT_LOG=day01=debug
+var logger = advent.NewLogger("day01")
+
+// Type Step represents a step from the input file, which contains a direction
+// (left or right) and the number of blocks to travel.
+type Step struct {
+	Direction Direction
+	Steps     int
+}
+
+// Type Direction represents either Left or Right.
+type Direction int
+
+// Direction constants.
+const (
+	Left Direction = iota
+	Right
+)
+
+// Type Facing represents what direction we're facing.
+type Facing int
+
+// Facing constants.
+const (
+	North Facing = iota
+	East
+	South
+	West
+)
+
+// Vectors for moving one block in each direction we can face.
+var vectors = map[Facing]grid.Point{
+	North: grid.Up,
+	East:  grid.Right,
+	South: grid.Down,
+	West:  grid.Left,
+}
+
+// Type Visited stores the coordinates we've been to.
+type Visited struct {
+	*grid.Sparse[bool]
+}
+
+// NewVisited creates an empty Visited map.
+func NewVisited() *Visited {
+	return &Visited{grid.NewSparse[bool]()}
+}
+
+func init() {
+	advent.Register(1, advent.SolverFunc(Solve))
+}
+
+// Solve finds how far away the Easter Bunny HQ is. For part 1 it's at the end
+// of the steps; for part 2 it's the first location that we visit twice.
+func Solve(part int, in *advent.Input) (advent.Result, error) {
+	// Get the list of steps to follow.
+	steps, err := ParseInput(in)
+	if err != nil {
+		return advent.Result{}, err
+	}
+
+	// Track our offsets starting at 0,0 and facing north to find where the
+	// directions lead to.
+	start := grid.Point{}
+	position, facing := start, North
+
+	// Also keep track of where we've been. The Easter Bunny HQ is at the first
+	// location that we visit twice.
+	visited := NewVisited()
+	visited.Visit(start)
+
+	// Follow the steps.
+	for _, step := range steps {
+		// Turn first.
+		facing.Turn(step.Direction)
+
+		// Print output for debugging.
+		logger.Debug("Step: %v - Now Facing: %v - Coords: %s\n", step, facing, position)
+
+		// Part 1 only cares about where the steps end up.
+		if part == 1 {
+			position = facing.Move(position, step.Steps)
+			continue
+		}
+
+		done := visited.Travel(&position, facing, step.Steps)
+		if done {
+			break
+		}
+	}
+
+	// And our verdict is...
+	return advent.Answer(position.Manhattan(start))
+}
+
+// Turn calculates what direction we're facing.
+func (f *Facing) Turn(direction Direction) {
+	// Rotate our direction of facing first.
+	if direction == Right {
+		*f += 1
+	} else {
+		*f -= 1
+	}
+
+	// And bounds check it.
+	if *f < North {
+		*f = West
+	} else if *f > West {
+		*f = North
+	}
+}
+
+// Move returns the coordinate that's a distance away in the direction we're
+// facing.
+func (f Facing) Move(p grid.Point, distance int) grid.Point {
+	return p.Add(vectors[f].Scale(distance))
+}
+
+// Travel moves our position along a vector and returns true if we've stepped
+// over the same position twice.
+func (v *Visited) Travel(p *grid.Point, facing Facing, distance int) bool {
+	// Loop for the distance desired.
+	for i := 0; i < distance; i++ {
+		// Move our position along the vector.
+		*p = facing.Move(*p, 1)
+
+		// Mark it as visited. This also tells us whether we stepped over the
+		// spot twice, so we can return true if so.
+		if v.Visit(*p) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Visit marks a spot we've visited and returns true if it's a duplicate spot.
+func (v *Visited) Visit(c grid.Point) bool {
+	logger.Debug("Visit coord: %v\n", c)
+	if v.Has(c) {
+		logger.Debug("We stepped back over our tracks at %v!\n", c)
+		return true
+	}
+	v.Set(c, true)
+	return false
+}
+
+// ParseInput parses the input text file and returns an array of Steps.
+func ParseInput(in *advent.Input) ([]Step, error) {
+	// Make the buffer of steps.
+	steps := []Step{}
+
+	for in.Scan() {
+		line := in.Text()
+
+		// Look for steps. Steps look like "R5" or "L2": a direction and a
+		// number of blocks to travel that direction.
+		var offset int
+		for _, field := range strings.Split(line, ",") {
+			step := strings.TrimSpace(field)
+			column := offset + strings.Index(field, step) + 1
+			offset += len(field) + 1
+
+			// A bad step is skipped, unless we're in strict mode.
+			var err error
+			if len(step) < 2 {
+				err = in.Errorf(column, step, "Found an invalid step entry")
+			} else if blocks, atoiErr := strconv.Atoi(step[1:]); atoiErr != nil {
+				err = in.Errorf(column+1, step[1:], "Invalid number of blocks")
+			} else if step[0] == 'R' {
+				steps = append(steps, Step{Right, blocks})
+			} else if step[0] == 'L' {
+				steps = append(steps, Step{Left, blocks})
+			} else {
+				err = in.Errorf(column, step, "Found an invalid step entry")
+			}
+
+			if err != nil {
+				if err = in.Skip(err); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	return steps, in.Err()
+}
@@ -0,0 +1,146 @@
+package day02
+
+import (
+	"strings"
+
+	"github.com/kirsle/goadvent2016/advent"
+	"github.com/kirsle/goadvent2016/advent/grid"
+)
+
+// For debug output: export ADVENT_LOG=day02=debug
+var logger = advent.NewLogger("day02")
+
+// Line represents a line of steps from the input file.
+type Line struct {
+	Moves []Direction
+}
+
+// Direction represents a cardinal direction (Up, Right, Down, Left)
+type Direction int
+
+// Direction constants.
+const (
+	Up Direction = iota
+	Right
+	Down
+	Left
+)
+
+// Vectors for moving the pointer in each direction.
+var vectors = map[Direction]grid.Point{
+	Up:    grid.Up,
+	Right: grid.Right,
+	Down:  grid.Down,
+	Left:  grid.Left,
+}
+
+// The keypad.
+var Keypad = grid.FromRows([][]string{
+	{" ", " ", "1", " ", " "},
+	{" ", "2", "3", "4", " "},
+	{"5", "6", "7", "8", "9"},
+	{" ", "A", "B", "C", " "},
+	{" ", " ", "D", " ", " "},
+})
+
+func init() {
+	advent.Register(2, advent.SolverFunc(Solve))
+}
+
+// Solve finds the bathroom pass code. Only part 2 (the diamond shaped keypad)
+// is implemented.
+func Solve(part int, in *advent.Input) (advent.Result, error) {
+	if part != 2 {
+		return advent.Result{}, advent.ErrNotImplemented
+	}
+
+	// Get the list of steps to follow.
+	lines, err := ParseInput(in)
+	if err != nil {
+		return advent.Result{}, err
+	}
+
+	// We start on the number '5', which is on the left corner of the keypad
+	// at (0,2). We'll work with coordinates and then use a converter function
+	// to tell us what digit is at a given coordinate.
+	pointer, passcode := grid.Pt(0, 2), make([]string, len(lines))
+
+	logger.Debug("Start at number: %s (at %s)\n", GetNumber(pointer), pointer)
+
+	// Check each line of instructions.
+	for i, line := range lines {
+		for _, move := range line.Moves {
+			// Move our pointer.
+			success := MovePointer(&pointer, move)
+
+			logger.Debug("Line %d: move %d to position %s - valid: %v - on key: %s\n", i, move, pointer, success, GetNumber(pointer))
+		}
+
+		// What digit is here?
+		digit := GetNumber(pointer)
+		logger.Debug("Got pass code digit: %s\n", digit)
+		passcode[i] = digit
+	}
+
+	return advent.Answer(strings.Join(passcode, ""))
+}
+
+// MovePointer attempts to move the pointer by 1 in a given direction, with
+// bounds checking so it won't move into an invalid space. Returns true if the
+// move was acceptable.
+func MovePointer(p *grid.Point, d Direction) bool {
+	// See whether the next spot is on the board and there's a valid digit
+	// there, and move our coordinates if its OK.
+	next := p.Add(vectors[d])
+	if !CanMove(next) {
+		return false
+	}
+
+	*p = next
+	return true
+}
+
+// GetNumber returns the number at the given coordinate.
+func GetNumber(p grid.Point) string {
+	return Keypad.Get(p)
+}
+
+// CanMove returns whether the coordinate is an actual number on the keypad.
+func CanMove(p grid.Point) bool {
+	return Keypad.InBounds(p) && Keypad.Get(p) != " "
+}
+
+// ParseInput parses the input text file and returns an array of Steps.
+func ParseInput(in *advent.Input) ([]Line, error) {
+	// Make the buffer of lines.
+	lines := []Line{}
+
+	for in.Scan() {
+		line := in.Text()
+
+		// Look for the individual direction steps on this line.
+		row := Line{}
+		for i := 0; i < len(line); i++ {
+			char := line[i]
+			if char == 'U' {
+				row.Moves = append(row.Moves, Up)
+			} else if char == 'R' {
+				row.Moves = append(row.Moves, Right)
+			} else if char == 'D' {
+				row.Moves = append(row.Moves, Down)
+			} else if char == 'L' {
+				row.Moves = append(row.Moves, Left)
+			} else {
+				// Skip the bad character, unless we're in strict mode.
+				err := in.Errorf(i+1, string(char), "Unexpected character in input file")
+				if err = in.Skip(err); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		lines = append(lines, row)
+	}
+
+	return lines, in.Err()
+}
@@ -0,0 +1,154 @@
+package day03
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/kirsle/goadvent2016/advent"
+)
+
+// For debug output: export ADVENT_LOG=day03=debug
+var logger = advent.NewLogger("day03")
+
+// FieldRegexp matches each of the numbers on a line.
+var FieldRegexp = regexp.MustCompile(`\S+`)
+
+// Type Line represents a literal line of numbers from the input file.
+type Line struct {
+	A int
+	B int
+	C int
+}
+
+// Type Triangle represents three dimensions of a triangle's edges.
+type Triangle struct {
+	A int
+	B int
+	C int
+}
+
+func init() {
+	advent.Register(3, advent.SolverFunc(Solve))
+}
+
+// Solve counts the valid triangles in the input.
+func Solve(part int, in *advent.Input) (advent.Result, error) {
+	// Parse the input file into an array of lines of numbers.
+	inputLines, err := ParseInput(in)
+	if err != nil {
+		return advent.Result{}, err
+	}
+
+	// In part 2, the triangles in the input are arranged vertically! In part 1
+	// we simply use `inputLines` as the `triangles` list.
+	var triangles []Triangle
+	if part == 1 {
+		for _, line := range inputLines {
+			triangles = append(triangles, Triangle(line))
+		}
+	} else {
+		triangles, err = ParseTriangles(inputLines)
+		if err != nil {
+			return advent.Result{}, err
+		}
+	}
+
+	// Look for invalid triangles.
+	var invalid int = 0
+	for i, triangle := range triangles {
+		logger.Trace("Triangle: %v", triangle)
+		if !triangle.IsValid() {
+			invalid++
+			logger.Debug("Invalid triangle at line %d: %v", i, triangle)
+		}
+	}
+
+	result := advent.Result{Answer: len(triangles) - invalid}
+	result.Diagnose("Of %d triangles, %d are valid and %d are not valid",
+		len(triangles),
+		len(triangles)-invalid,
+		invalid,
+	)
+	return result, nil
+}
+
+// ParseTriangles turns the input lines of numbers into triangles.
+func ParseTriangles(lines []Line) ([]Triangle, error) {
+	result := []Triangle{}
+
+	if len(lines)%3 != 0 {
+		return nil, fmt.Errorf("The vertical triangles need a multiple of 3 lines, but there are %d", len(lines))
+	}
+
+	// We need to scan the input 3 lines at a time and produce 3 triangles
+	// from each column.
+	for i := 0; i < len(lines); i += 3 {
+		rows := lines[i : i+3]
+
+		result = append(result,
+			Triangle{rows[0].A, rows[1].A, rows[2].A},
+			Triangle{rows[0].B, rows[1].B, rows[2].B},
+			Triangle{rows[0].C, rows[1].C, rows[2].C},
+		)
+	}
+
+	return result, nil
+}
+
+// IsValid validates a triangle.
+func (t Triangle) IsValid() bool {
+	// Test all the permutations of sides to see if the triangle is invalid.
+	permutations := []bool{
+		t.A+t.B > t.C,
+		t.A+t.C > t.B,
+		t.B+t.C > t.A,
+	}
+	for _, ok := range permutations {
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ParseInput parses the lines of integers from the input file.
+func ParseInput(in *advent.Input) ([]Line, error) {
+	// The triangles parsed from the file.
+	result := []Line{}
+
+Lines:
+	for in.Scan() {
+		line := in.Text()
+
+		// Make sure we got a valid triplet
+		fields := FieldRegexp.FindAllStringIndex(line, -1)
+		if len(fields) != 3 {
+			err := in.Errorf(0, line, "Expected 3 numbers but found %d", len(fields))
+			if err = in.Skip(err); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		// Convert the numbers on this line to ints.
+		sides := []int{}
+		for _, field := range fields {
+			side := line[field[0]:field[1]]
+			value, err := strconv.Atoi(side)
+			if err != nil {
+				err = in.Errorf(field[0]+1, side, "Not a number")
+				if err = in.Skip(err); err != nil {
+					return nil, err
+				}
+				continue Lines
+			}
+			sides = append(sides, value)
+		}
+
+		result = append(result, Line{sides[0], sides[1], sides[2]})
+	}
+
+	return result, in.Err()
+}
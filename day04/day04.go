@@ -1,17 +1,19 @@
-package main
+package day04
 
 import (
-	"bufio"
 	"errors"
 	"fmt"
-	"log"
-	"os"
 	"regexp"
 	"sort"
 	"strconv"
 	"strings"
+
+	"github.com/kirsle/goadvent2016/advent"
 )
 
+// For debug output: export ADVENT_LOG=day04=debug
+var logger = advent.NewLogger("day04")
+
 // Type Room represents a parsed room.
 type Room struct {
 	EncryptedName string
@@ -22,64 +24,89 @@ type Room struct {
 // Regular expressions.
 var RE_RoomName = regexp.MustCompile(`^([a-z\-]+?)\-(\d+?)\[([a-z]+?)\]$`)
 
-func main() {
-	if len(os.Args) < 2 {
-		fmt.Println("Usage: main.go <input file>")
-		os.Exit(1)
-	}
+func init() {
+	advent.Register(4, advent.SolverFunc(Solve))
+}
 
-	// Read the input file.
-	inputLines := ReadFile(os.Args[1])
+// The decrypted room name that part 2 of the puzzle is looking for.
+const NorthPoleRoom = "northpole object"
 
+// Solve validates the room names. Part 1 sums the sectors of the real rooms
+// and part 2 decrypts their names to find where the North Pole objects are.
+func Solve(part int, in *advent.Input) (advent.Result, error) {
 	// Parse each room.
+	result := advent.Result{}
 	sectors := 0
-	for _, line := range inputLines {
-		room, err := ParseRoom(line)
+	for in.Scan() {
+		room, err := ParseRoom(in.Text())
 		if err != nil {
-			log.Printf("Failed to parse room %s: %v", line, err)
+			if err = in.Skip(in.Wrap(err)); err != nil {
+				return result, err
+			}
 			continue
 		}
 
 		// Is valid?
 		if err = room.Validate(); err != nil {
-			Debug("Invalid room '%s': %s", room.EncryptedName, err)
+			logger.Debug("Invalid room '%s': %s", room.EncryptedName, err)
 			continue
 		}
 
 		// Sum up the sector ID's of the real rooms.
 		sectors += room.Sector
 
-		// Print its name.
-		fmt.Printf("DECODED ROOM NAME: %s  %s  (Sector %d)\n",
-			room.EncryptedName,
-			room.Decrypt(),
-			room.Sector,
-		)
+		// Decrypt its name.
+		if part == 2 {
+			name := room.Decrypt()
+			logger.Debug("Decoded room name: %s  %s  (Sector %d)", room.EncryptedName, name, room.Sector)
+
+			if strings.Contains(name, NorthPoleRoom) {
+				result.Answer = room.Sector
+				result.Diagnose("Found %q in room %s (Sector %d)", name, room.EncryptedName, room.Sector)
+			}
+		}
 	}
 
-	fmt.Printf("Sum of the sectors of real rooms: %d\n", sectors)
+	if err := in.Err(); err != nil {
+		return result, err
+	}
+
+	if part == 1 {
+		result.Answer = sectors
+	} else if result.Answer == nil {
+		return result, fmt.Errorf("No room is named like %q", NorthPoleRoom)
+	}
+	return result, nil
 }
 
-// ParseRoom turns a room name into a Room object.
+// ParseRoom turns a room name into a Room object. Errors are returned as an
+// *advent.ParseError pointing at the bad part of the name.
 func ParseRoom(name string) (Room, error) {
 	// The parsed room object.
 	room := Room{}
 
 	// Apply the regexp first.
-	match := RE_RoomName.FindStringSubmatch(name)
+	match := RE_RoomName.FindStringSubmatchIndex(name)
 	if match == nil {
-		return room, errors.New("Room does not match the regular expression.")
+		return room, &advent.ParseError{
+			Token: name,
+			Err:   errors.New("Room does not match the regular expression."),
+		}
 	}
 
 	// Sector number.
-	sector, err := strconv.Atoi(match[2])
+	sector, err := strconv.Atoi(name[match[4]:match[5]])
 	if err != nil {
-		return room, errors.New("Invalid sector number")
+		return room, &advent.ParseError{
+			Column: match[4] + 1,
+			Token:  name[match[4]:match[5]],
+			Err:    errors.New("Invalid sector number"),
+		}
 	}
 
-	room.EncryptedName = match[1]
+	room.EncryptedName = name[match[2]:match[3]]
 	room.Sector = sector
-	room.Checksum = match[3]
+	room.Checksum = name[match[6]:match[7]]
 	return room, nil
 }
 
@@ -155,7 +182,7 @@ func (r Room) Validate() error {
 	}
 
 	// Debugging
-	Debug("Name: %v Sorted: %v [%s != %s]\n", r.EncryptedName, pl, r.Checksum, string(checksum))
+	logger.Debug("Name: %v Sorted: %v [%s != %s]\n", r.EncryptedName, pl, r.Checksum, string(checksum))
 
 	// Validate whether it matches.
 	if string(checksum) != r.Checksum {
@@ -190,36 +217,3 @@ func (slice PairList) Less(i, j int) bool {
 func (slice PairList) Swap(i, j int) {
 	slice[i], slice[j] = slice[j], slice[i]
 }
-
-// ReadFile slurps the lines of text from a file.
-func ReadFile(file string) []string {
-	fh, err := os.Open(file)
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer fh.Close()
-
-	lines := []string{}
-
-	scanner := bufio.NewScanner(fh)
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if len(line) == 0 {
-			continue
-		}
-		lines = append(lines, line)
-	}
-
-	if err := scanner.Err(); err != nil {
-		log.Fatal(err)
-	}
-
-	return lines
-}
-
-// Debug prints a debug message when $DEBUG=1.
-func Debug(tmpl string, a ...interface{}) {
-	if os.Getenv("DEBUG") != "" {
-		fmt.Printf(tmpl, a...)
-	}
-}
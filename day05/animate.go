@@ -0,0 +1,85 @@
+package day05
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"time"
+)
+
+// FrameRate is how often the animation redraws the screen.
+const FrameRate = 50 * time.Millisecond
+
+// Type Animation draws the password being cracked like in the movies: one line
+// on the terminal that's redrawn in place, with random characters flickering
+// in the positions that haven't been found yet.
+type Animation struct {
+	w          io.Writer
+	password   *Password
+	startIndex int
+	started    time.Time
+	drawn      time.Time
+}
+
+// NewAnimation creates an animation of a password on a terminal. It returns
+// nil if the file isn't a terminal, so the caller can fall back to plain
+// output.
+func NewAnimation(fh *os.File, password *Password, startIndex int) *Animation {
+	if !IsTerminal(fh) {
+		return nil
+	}
+
+	return &Animation{
+		w:          fh,
+		password:   password,
+		startIndex: startIndex,
+		started:    time.Now(),
+	}
+}
+
+// IsTerminal tells whether a file is a terminal rather than a pipe or a
+// regular file.
+func IsTerminal(fh *os.File) bool {
+	stat, err := fh.Stat()
+	if err != nil {
+		return false
+	}
+	return stat.Mode()&os.ModeCharDevice != 0
+}
+
+// Update redraws the animation for the next index to be searched. It only
+// draws as often as the frame rate, unless `force` is true.
+func (a *Animation) Update(index int, force bool) {
+	now := time.Now()
+	if !force && now.Sub(a.drawn) < FrameRate {
+		return
+	}
+	a.drawn = now
+
+	// Count the hashes per second.
+	var rate float64
+	if elapsed := now.Sub(a.started).Seconds(); elapsed > 0 {
+		rate = float64(index-a.startIndex) / elapsed
+	}
+
+	fmt.Fprintf(a.w, "\r\033[KCracking: %s  %.0f hashes/s  index %d", a.Glyphs(), rate, index)
+}
+
+// Glyphs returns the password with random characters in its unfilled
+// positions.
+func (a *Animation) Glyphs() string {
+	glyphs := []rune(a.password.String())
+	for i, found := range a.password.Filled {
+		if !found {
+			glyphs[i] = rune("0123456789abcdef"[rand.Intn(16)])
+		}
+	}
+	return string(glyphs)
+}
+
+// Done draws the final frame and moves to the next line.
+func (a *Animation) Done(index int) {
+	a.Update(index, true)
+	fmt.Fprintln(a.w)
+}
@@ -0,0 +1,88 @@
+package day05
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// CheckpointInterval is how often the search progress is saved.
+const CheckpointInterval = 10 * time.Second
+
+// Type Checkpoint is the progress of a password search, so that it can be
+// resumed after the program is stopped.
+type Checkpoint struct {
+	DoorID     string   `json:"door_id"`
+	Difficulty int      `json:"difficulty"`
+	Strategy   Strategy `json:"strategy"`
+	Hash       string   `json:"hash"`
+	Index      int      `json:"index"` // The next index to search
+	Password   Password `json:"password"`
+}
+
+// Type Checkpoints holds the checkpoint for each part of the puzzle, which are
+// all saved in the same file.
+type Checkpoints map[int]*Checkpoint
+
+// LoadCheckpoints reads a checkpoint file. A file that doesn't exist yet has
+// no checkpoints in it.
+func LoadCheckpoints(filename string) (Checkpoints, error) {
+	data, err := ioutil.ReadFile(filename)
+	if os.IsNotExist(err) {
+		return Checkpoints{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	checkpoints := Checkpoints{}
+	if err := json.Unmarshal(data, &checkpoints); err != nil {
+		return nil, err
+	}
+	return checkpoints, nil
+}
+
+// Save writes the checkpoint for a part into the checkpoint file, keeping the
+// other parts' checkpoints. The file is replaced in one step so that an
+// interruption can't leave it half written.
+func (c *Checkpoint) Save(filename string, part int) error {
+	checkpoints, err := LoadCheckpoints(filename)
+	if err != nil {
+		return err
+	}
+	checkpoints[part] = c
+
+	data, err := json.MarshalIndent(checkpoints, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(filename), filepath.Base(filename)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filename)
+}
+
+// Matches tells whether a checkpoint is for the same search as another one,
+// so that it's safe to resume from.
+func (c *Checkpoint) Matches(other *Checkpoint) error {
+	if c.DoorID != other.DoorID {
+		return errors.New("The checkpoint is for a different Door ID")
+	}
+	if c.Difficulty != other.Difficulty || c.Strategy != other.Strategy || c.Hash != other.Hash {
+		return errors.New("The checkpoint is for different cracker settings")
+	}
+	return nil
+}
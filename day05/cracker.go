@@ -0,0 +1,270 @@
+package day05
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"fmt"
+	"hash"
+	"runtime"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// ChunkSize is how many indexes a search worker hashes at a time.
+const ChunkSize = 10000
+
+// PendingChunks is how many chunks each search worker can get ahead by while
+// waiting on a slow chunk before it, so the chunks held on to stay bounded.
+const PendingChunks = 4
+
+// Type Strategy is how an interesting hash fills in the password.
+type Strategy int
+
+// Strategy constants.
+const (
+	// Sequential fills the password from left to right with the first hex
+	// digit after the zeroes (part 1 of the puzzle).
+	Sequential Strategy = iota
+
+	// Positional uses the first hex digit after the zeroes as the position to
+	// fill and the next digit as its value (part 2 of the puzzle).
+	Positional
+)
+
+// HashFuncs are the hash functions that the Cracker can use, by name.
+var HashFuncs = map[string]func() hash.Hash{
+	"md5":    md5.New,
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+}
+
+// HashNames returns the sorted names of the hash functions.
+func HashNames() []string {
+	names := []string{}
+	for name := range HashFuncs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Type Cracker finds a password by hashing the Door ID with increasing
+// indexes and looking for interesting hashes, which start with a number of
+// zeroes in hex.
+type Cracker struct {
+	DoorID     string
+	Difficulty int              // How many leading zeroes make a hash interesting
+	Strategy   Strategy         // How an interesting hash fills the password
+	Hash       func() hash.Hash // The hash function
+	Workers    int              // How many hashes to search at once
+}
+
+// NewCracker creates a Cracker with the settings from the puzzle: MD5 hashes
+// starting with five zeroes, using every CPU core.
+func NewCracker(doorID string, strategy Strategy) *Cracker {
+	return &Cracker{
+		DoorID:     doorID,
+		Difficulty: 5,
+		Strategy:   strategy,
+		Hash:       md5.New,
+		Workers:    runtime.NumCPU(),
+	}
+}
+
+// Type Hit is an interesting hash found by the search.
+type Hit struct {
+	Index  int
+	Digest []byte
+}
+
+// Nibble returns the n'th hex digit of the hash.
+func (h Hit) Nibble(n int) byte {
+	b := h.Digest[n/2]
+	if n%2 == 0 {
+		return b >> 4
+	}
+	return b & 0x0F
+}
+
+// Hex returns the n'th hex digit of the hash as a character.
+func (h Hit) Hex(n int) rune {
+	return rune("0123456789abcdef"[h.Nibble(n)])
+}
+
+// Crack searches for hashes until the password is filled in. The `filled`
+// function, if not nil, is called each time a new character is found.
+//
+// It returns the index after the last one that was checked.
+func (c *Cracker) Crack(ctx context.Context, password *Password, start int, filled func(Hit), progress func(int)) (int, error) {
+	if c.Difficulty+2 > c.Hash().Size()*2 {
+		return start, fmt.Errorf("Difficulty %d is too high for a %d byte hash", c.Difficulty, c.Hash().Size())
+	}
+
+	// Nothing to do if we resumed a search that had finished.
+	if password.Cracked() {
+		return start, nil
+	}
+
+	return c.Search(ctx, start, func(hit Hit) bool {
+		if c.Fill(password, hit) && filled != nil {
+			filled(hit)
+		}
+		return password.Cracked()
+	}, progress)
+}
+
+// Fill enters the password character from an interesting hash, using the
+// Cracker's strategy. It tells whether a new character was found.
+func (c *Cracker) Fill(password *Password, hit Hit) bool {
+	if c.Strategy == Sequential {
+		position := password.Next()
+		if position == -1 {
+			return false
+		}
+		return password.Fill(position, hit.Hex(c.Difficulty))
+	}
+
+	// Skip the hash if it's for an impossible position.
+	position := int(hit.Nibble(c.Difficulty))
+	if position >= PasswordLength {
+		return false
+	}
+	return password.Fill(position, hit.Hex(c.Difficulty+1))
+}
+
+// Search looks for interesting hashes with every index from `start` upwards,
+// spreading the work across the workers.
+//
+// The hits are given to the `found` function one at a time in index order, no
+// matter which worker found them, so the results are the same as a plain loop.
+// The search stops when `found` returns true or the context is cancelled.
+// It returns the index after the last one that was checked.
+//
+// If `progress` isn't nil, it's called with the next index to check each time
+// a chunk has been searched.
+func (c *Cracker) Search(ctx context.Context, start int, found func(Hit) bool, progress func(int)) (int, error) {
+	workers := c.Workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	// The workers claim chunks of indexes in order by bumping a counter. Each
+	// chunk takes a slot until its hits have been used, so the workers can
+	// only get so far ahead of a slow chunk.
+	var (
+		next    int64 = -1
+		results       = make(chan chunk, workers)
+		slots         = make(chan struct{}, PendingChunks*workers)
+		wg      sync.WaitGroup
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				select {
+				case slots <- struct{}{}:
+				case <-ctx.Done():
+					return
+				}
+
+				number := int(atomic.AddInt64(&next, 1))
+				hits := c.searchChunk(start + number*ChunkSize)
+				select {
+				case results <- chunk{number, hits}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	// Stop the workers and wait for them to exit before we return.
+	defer wg.Wait()
+	defer cancel()
+
+	// Chunks come back in whatever order the workers finish them, so hold on
+	// to them until all the chunks before them are in.
+	pending := map[int][]Hit{}
+	want := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return start + want*ChunkSize, ctx.Err()
+		case done := <-results:
+			pending[done.number] = done.hits
+		}
+
+		for {
+			hits, ok := pending[want]
+			if !ok {
+				break
+			}
+			delete(pending, want)
+			<-slots
+
+			for _, hit := range hits {
+				if found(hit) {
+					return hit.Index + 1, nil
+				}
+			}
+			want++
+
+			if progress != nil {
+				progress(start + want*ChunkSize)
+			}
+		}
+	}
+}
+
+// Type chunk is the hits found in the chunk of indexes
+// [start+number*ChunkSize, start+(number+1)*ChunkSize).
+type chunk struct {
+	number int
+	hits   []Hit
+}
+
+// searchChunk hashes one chunk of indexes and returns the interesting ones.
+func (c *Cracker) searchChunk(start int) []Hit {
+	var (
+		hits   []Hit
+		hasher = c.Hash()
+		input  = []byte(c.DoorID)
+		digest = make([]byte, 0, hasher.Size())
+	)
+
+	for index := start; index < start+ChunkSize; index++ {
+		input = strconv.AppendInt(input[:len(c.DoorID)], int64(index), 10)
+		hasher.Reset()
+		hasher.Write(input)
+		digest = hasher.Sum(digest[:0])
+
+		if c.interesting(digest) {
+			hits = append(hits, Hit{
+				Index:  index,
+				Digest: append([]byte(nil), digest...),
+			})
+		}
+	}
+
+	return hits
+}
+
+// interesting tells whether a digest starts with enough zeroes in hex.
+func (c *Cracker) interesting(digest []byte) bool {
+	for i := 0; i < c.Difficulty/2; i++ {
+		if digest[i] != 0 {
+			return false
+		}
+	}
+	if c.Difficulty%2 == 1 && digest[c.Difficulty/2]&0xF0 != 0 {
+		return false
+	}
+	return true
+}
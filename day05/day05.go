@@ -0,0 +1,252 @@
+package day05
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kirsle/goadvent2016/advent"
+)
+
+// For debug output: export ADVENT_LOG=day05=debug
+var logger = advent.NewLogger("day05")
+
+// Type Password contains the slots for the password.
+const PasswordLength = 8
+
+type Password struct {
+	Code   [PasswordLength]rune // The actual characters of the password.
+	Filled [PasswordLength]bool // Layer mask for which runes we've unlocked.
+}
+
+// Type Solver cracks the password, with options from the command line.
+type Solver struct {
+	Animate    bool   // Show the password being cracked on the terminal.
+	Difficulty int    // How many leading zeroes make a hash interesting, or 0 for the puzzle's 5.
+	HashName   string // Which hash function to use.
+	Checkpoint string // File to save the search progress to.
+	Resume     bool   // Pick up the search from the checkpoint file.
+}
+
+func init() {
+	advent.Register(5, &Solver{})
+}
+
+// Flags adds the day 5 options to the `advent run` command.
+func (s *Solver) Flags(fs *flag.FlagSet) {
+	fs.BoolVar(&s.Animate, "animate", false, "Day 5: animate the password being cracked when stdout is a terminal")
+	// On the command line the difficulty is always given, so 0 is a mistake
+	// rather than asking for the default.
+	s.Difficulty = 5
+	fs.Func("difficulty", "Day 5: number of leading zeroes in an interesting hash (default 5)", func(value string) error {
+		difficulty, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		if difficulty < 1 {
+			return fmt.Errorf("must be at least 1, got %d", difficulty)
+		}
+		s.Difficulty = difficulty
+		return nil
+	})
+	fs.StringVar(&s.HashName, "hash", "md5", "Day 5: hash function: "+strings.Join(HashNames(), ", "))
+	fs.StringVar(&s.Checkpoint, "checkpoint", "", "Day 5: file to save the search progress to every so often")
+	fs.BoolVar(&s.Resume, "resume", false, "Day 5: resume the search from the --checkpoint file")
+}
+
+// Solve cracks the password for the Door ID found in the input file. Part 1
+// fills the password in order and part 2 has each hash say which position it
+// fills.
+func (s *Solver) Solve(part int, in *advent.Input) (advent.Result, error) {
+	cracker, err := s.Cracker(part)
+	if err != nil {
+		return advent.Result{}, err
+	}
+
+	// The input file holds the Door ID on its first line.
+	if !in.Scan() {
+		if err := in.Err(); err != nil {
+			return advent.Result{}, err
+		}
+		return advent.Result{}, errors.New("The input file doesn't contain a Door ID")
+	}
+
+	cracker.DoorID = in.Text()
+	password := Password{}
+
+	// Pick up where we left off?
+	checkpoint, err := s.loadCheckpoint(part, cracker)
+	if err != nil {
+		return advent.Result{}, err
+	}
+	password = checkpoint.Password
+	start := checkpoint.Index
+
+	// Show off the cracking in progress, if asked to and there's a terminal
+	// to show it on.
+	var animation *Animation
+	if s.Animate {
+		animation = NewAnimation(os.Stdout, &password, start)
+	}
+
+	// Save our progress every so often, and when the search stops.
+	saved := time.Now()
+	save := func(index int) error {
+		if s.Checkpoint == "" {
+			return nil
+		}
+		checkpoint.Index = index
+		checkpoint.Password = password
+		saved = time.Now()
+		logger.Debug("Saving checkpoint at index %d: %s", index, password.String())
+		return checkpoint.Save(s.Checkpoint, part)
+	}
+
+	progress := func(index int) {
+		if animation != nil {
+			animation.Update(index, false)
+		}
+		if time.Since(saved) >= CheckpointInterval {
+			if err := save(index); err != nil {
+				logger.Info("Couldn't save the checkpoint: %s", err)
+			}
+		}
+	}
+
+	// Search for that password.
+	last, err := cracker.Crack(in.Context(), &password, start, func(hit Hit) {
+		logger.Debug("Index=%d hash=%x password=%s\n", hit.Index, hit.Digest, password.String())
+		if animation != nil {
+			animation.Update(hit.Index, true)
+		} else {
+			logger.Info("Cracking: %s", password.String())
+		}
+	}, progress)
+	if animation != nil {
+		animation.Done(last)
+	}
+	if saveErr := save(last); saveErr != nil && err == nil {
+		err = saveErr
+	}
+	if err != nil {
+		if s.Checkpoint != "" {
+			logger.Info("Stopped at index %d; run with --resume to continue", last)
+		}
+		return advent.Result{}, err
+	}
+
+	return advent.Answer(password.String())
+}
+
+// loadCheckpoint returns the checkpoint to resume the search from, or a new
+// one starting at the beginning.
+func (s *Solver) loadCheckpoint(part int, cracker *Cracker) (*Checkpoint, error) {
+	hashName := s.HashName
+	if hashName == "" {
+		hashName = "md5"
+	}
+
+	checkpoint := &Checkpoint{
+		DoorID:     cracker.DoorID,
+		Difficulty: cracker.Difficulty,
+		Strategy:   cracker.Strategy,
+		Hash:       hashName,
+	}
+	if !s.Resume {
+		return checkpoint, nil
+	}
+	if s.Checkpoint == "" {
+		return nil, errors.New("--resume needs a --checkpoint file to resume from")
+	}
+
+	checkpoints, err := LoadCheckpoints(s.Checkpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	saved, ok := checkpoints[part]
+	if !ok {
+		logger.Info("No checkpoint for part %d; starting from the beginning", part)
+		return checkpoint, nil
+	}
+	if err := saved.Matches(checkpoint); err != nil {
+		return nil, err
+	}
+
+	logger.Info("Resuming from index %d: %s", saved.Index, saved.Password.String())
+	return saved, nil
+}
+
+// Cracker creates the password Cracker for a part of the puzzle.
+func (s *Solver) Cracker(part int) (*Cracker, error) {
+	strategy := Sequential
+	if part == 2 {
+		strategy = Positional
+	}
+	cracker := NewCracker("", strategy)
+
+	// The zero value of the Solver uses the puzzle's settings.
+	if s.Difficulty < 0 {
+		return nil, fmt.Errorf("Difficulty must not be negative, got %d", s.Difficulty)
+	} else if s.Difficulty > 0 {
+		cracker.Difficulty = s.Difficulty
+	}
+	if s.HashName != "" {
+		hash, ok := HashFuncs[s.HashName]
+		if !ok {
+			return nil, fmt.Errorf("Unknown hash function: %s", s.HashName)
+		}
+		cracker.Hash = hash
+	}
+
+	return cracker, nil
+}
+
+// Fill enters a password symbol, if that position wasn't already found, and
+// tells whether it was.
+func (p *Password) Fill(position int, value rune) bool {
+	if p.Filled[position] {
+		return false
+	}
+	p.Code[position] = rune(value)
+	p.Filled[position] = true
+	return true
+}
+
+// String returns a string version of the password.
+func (p *Password) String() string {
+	result := []string{}
+	for i, _ := range p.Code {
+		if p.Filled[i] {
+			result = append(result, string(p.Code[i]))
+		} else {
+			result = append(result, "-")
+		}
+	}
+	return strings.Join(result, "")
+}
+
+// Next returns the first position that hasn't been filled, or -1 if the
+// password is cracked.
+func (p *Password) Next() int {
+	for i, found := range p.Filled {
+		if !found {
+			return i
+		}
+	}
+	return -1
+}
+
+// Cracked tests whether the password was fully cracked.
+func (p *Password) Cracked() bool {
+	for _, found := range p.Filled {
+		if !found {
+			return false
+		}
+	}
+	return true
+}
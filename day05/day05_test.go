@@ -0,0 +1,322 @@
+package day05
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"flag"
+	"hash"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kirsle/goadvent2016/advent"
+)
+
+func TestSearch(t *testing.T) {
+	// The first interesting hashes for the example Door ID.
+	expected := []int{3231929, 5017308, 5278568, 5357525}
+
+	// The hits should come out in the same order no matter how many workers.
+	for _, workers := range []int{1, 4} {
+		cracker := NewCracker("abc", Positional)
+		cracker.Workers = workers
+
+		var result []int
+		_, err := cracker.Search(context.Background(), 3000000, func(hit Hit) bool {
+			result = append(result, hit.Index)
+			return len(result) == len(expected)
+		}, nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Output assertion error with %d workers: expected %v, got %v", workers, expected, result)
+		}
+	}
+}
+
+// Type slowHash is a hash that waits to be released before it hashes anything.
+type slowHash struct {
+	hash.Hash
+	release chan struct{}
+}
+
+func (h *slowHash) Write(p []byte) (int, error) {
+	<-h.release
+	return h.Hash.Write(p)
+}
+
+func TestSearchSlowChunk(t *testing.T) {
+	// The first chunk to start is held up, and the workers should only get so
+	// far ahead of it. Each chunk creates one hasher.
+	var (
+		calls   int64
+		release = make(chan struct{})
+	)
+	cracker := NewCracker("abc", Sequential)
+	cracker.Difficulty = 3
+	cracker.Workers = 2
+	cracker.Hash = func() hash.Hash {
+		if atomic.AddInt64(&calls, 1) == 1 {
+			return &slowHash{md5.New(), release}
+		}
+		return md5.New()
+	}
+
+	done := make(chan error)
+	go func() {
+		_, err := cracker.Search(context.Background(), 0, func(hit Hit) bool {
+			return true
+		}, nil)
+		done <- err
+	}()
+
+	time.Sleep(200 * time.Millisecond)
+	limit := int64(PendingChunks * cracker.Workers)
+	if n := atomic.LoadInt64(&calls); n > limit {
+		t.Errorf("Expected at most %d chunks to start while one was held up, got %d", limit, n)
+	}
+
+	close(release)
+	if err := <-done; err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+}
+
+func TestFill(t *testing.T) {
+	// The hash of "abc3231929" is 00000155f8105dff7f56ee10fa9b9abd.
+	hit := Hit{Index: 3231929, Digest: []byte{0x00, 0x00, 0x01, 0x55, 0xf8}}
+
+	tests := []struct {
+		strategy Strategy
+		expect   string
+	}{
+		{Sequential, "1-------"},
+		{Positional, "-5------"},
+	}
+
+	for _, test := range tests {
+		password := Password{}
+		if !NewCracker("abc", test.strategy).Fill(&password, hit) {
+			t.Errorf("Expected strategy %d to fill a character", test.strategy)
+		}
+		if password.String() != test.expect {
+			t.Errorf("Output assertion error: expected %s, got %s", test.expect, password.String())
+		}
+	}
+}
+
+func TestInteresting(t *testing.T) {
+	tests := []struct {
+		difficulty int
+		digest     []byte
+		expect     bool
+	}{
+		{5, []byte{0x00, 0x00, 0x01, 0x55}, true},
+		{5, []byte{0x00, 0x00, 0x10, 0x55}, false},
+		{6, []byte{0x00, 0x00, 0x01, 0x55}, false},
+		{6, []byte{0x00, 0x00, 0x00, 0x55}, true},
+	}
+
+	for _, test := range tests {
+		cracker := NewCracker("abc", Positional)
+		cracker.Difficulty = test.difficulty
+		if result := cracker.interesting(test.digest); result != test.expect {
+			t.Errorf("Difficulty %d for %x: expected %v, got %v", test.difficulty, test.digest, test.expect, result)
+		}
+	}
+}
+
+func TestDifficulty(t *testing.T) {
+	for _, value := range []string{"0", "-1", "x"} {
+		solver := &Solver{}
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(ioutil.Discard)
+		solver.Flags(fs)
+		if err := fs.Parse([]string{"--difficulty", value}); err == nil {
+			t.Errorf("Expected an error for --difficulty %s", value)
+		}
+	}
+
+	if _, err := (&Solver{Difficulty: -1}).Cracker(1); err == nil {
+		t.Errorf("Expected an error for a negative difficulty")
+	}
+
+	// The default is the puzzle's difficulty, both on the command line and
+	// for the zero value of the Solver.
+	solver := &Solver{}
+	solver.Flags(flag.NewFlagSet("test", flag.ContinueOnError))
+	for _, solver := range []*Solver{solver, {}} {
+		if cracker, err := solver.Cracker(1); err != nil || cracker.Difficulty != 5 {
+			t.Errorf("Expected the default difficulty of 5, got %v (%v)", cracker, err)
+		}
+	}
+}
+
+func TestCheckpoints(t *testing.T) {
+	dir, err := ioutil.TempDir("", "day05")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "checkpoint.json")
+
+	// Each part's checkpoint is kept in the same file.
+	first := &Checkpoint{DoorID: "abc", Difficulty: 5, Strategy: Sequential, Hash: "md5", Index: 20000}
+	first.Password.Fill(0, '1')
+	second := &Checkpoint{DoorID: "abc", Difficulty: 5, Strategy: Positional, Hash: "md5", Index: 40000}
+	second.Password.Fill(1, '5')
+
+	for part, checkpoint := range map[int]*Checkpoint{1: first, 2: second} {
+		if err := checkpoint.Save(filename, part); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	}
+
+	checkpoints, err := LoadCheckpoints(filename)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expected := Checkpoints{1: first, 2: second}
+	if !reflect.DeepEqual(checkpoints, expected) {
+		t.Errorf("Output assertion error: expected %+v, got %+v", expected, checkpoints)
+	}
+
+	if err := first.Matches(second); err == nil {
+		t.Errorf("Expected checkpoints with different strategies not to match")
+	}
+}
+
+// Type writerFunc is an io.Writer that calls a function.
+type writerFunc func(p []byte) (int, error)
+
+func (f writerFunc) Write(p []byte) (int, error) {
+	return f(p)
+}
+
+func TestResume(t *testing.T) {
+	dir, err := ioutil.TempDir("", "day05")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "checkpoint.json")
+
+	// An easier difficulty keeps the searches short.
+	solve := func(ctx context.Context, solver *Solver) (advent.Result, error) {
+		solver.Difficulty = 4
+		return solver.Solve(1, advent.NewInput(ctx, "test", strings.NewReader("abc")))
+	}
+
+	expected, err := solve(context.Background(), &Solver{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	// Stop the search as soon as it's found the first character, which is
+	// logged as it's found.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	spec := advent.LogSpec()
+	t.Cleanup(func() {
+		advent.ConfigureLogging(spec)
+		advent.SetLogOutput(os.Stderr)
+	})
+	advent.ConfigureLogging(spec + ",day05=info")
+	advent.SetLogOutput(writerFunc(func(p []byte) (int, error) {
+		if bytes.Contains(p, []byte("Cracking:")) {
+			cancel()
+		}
+		return len(p), nil
+	}))
+
+	if _, err := solve(ctx, &Solver{Checkpoint: filename}); err != context.Canceled {
+		t.Fatalf("Expected the search to be cancelled, got %v", err)
+	}
+
+	// The checkpoint was saved when the search stopped.
+	checkpoints, err := LoadCheckpoints(filename)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	saved, ok := checkpoints[1]
+	if !ok || saved.Index == 0 || saved.Password.Next() == 0 || saved.Password.Cracked() {
+		t.Fatalf("Expected a checkpoint part way through the search, got %+v", saved)
+	}
+
+	result, err := solve(context.Background(), &Solver{Checkpoint: filename, Resume: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if result.String() != expected.String() {
+		t.Errorf("Output assertion error: expected %s, got %s", expected, result)
+	}
+}
+
+func TestAnimation(t *testing.T) {
+	password := Password{}
+	password.Fill(1, '5')
+	password.Fill(4, 'e')
+
+	var buf bytes.Buffer
+	a := &Animation{
+		w:          &buf,
+		password:   &password,
+		startIndex: 1000,
+		started:    time.Now().Add(-time.Second),
+	}
+
+	// The filled positions stay put, and the rest flicker with hex digits.
+	glyphs := regexp.MustCompile(`^[0-9a-f]5[0-9a-f]{2}e[0-9a-f]{3}$`)
+	for i := 0; i < 10; i++ {
+		if result := a.Glyphs(); !glyphs.MatchString(result) {
+			t.Errorf("Glyphs assertion error: got %q", result)
+		}
+	}
+
+	// Each frame redraws the line in place.
+	frame := regexp.MustCompile(`^\r\033\[KCracking: [0-9a-f]5[0-9a-f]{2}e[0-9a-f]{3}  \d+ hashes/s  index 3000$`)
+	a.Update(3000, false)
+	if result := buf.String(); !frame.MatchString(result) {
+		t.Errorf("Update assertion error: got %q", result)
+	}
+
+	// Frames come no faster than the frame rate, unless forced.
+	buf.Reset()
+	a.Update(3000, false)
+	if buf.Len() > 0 {
+		t.Errorf("Expected no frame so soon after the last one, got %q", buf.String())
+	}
+	a.Update(3000, true)
+	if result := buf.String(); !frame.MatchString(result) {
+		t.Errorf("Forced Update assertion error: got %q", result)
+	}
+
+	// The last frame ends the line.
+	buf.Reset()
+	a.Done(3000)
+	if result := buf.String(); !strings.HasSuffix(result, "index 3000\n") {
+		t.Errorf("Done assertion error: got %q", result)
+	}
+}
+
+func TestAnimationNotTerminal(t *testing.T) {
+	fh, err := ioutil.TempFile("", "day05")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer os.Remove(fh.Name())
+	defer fh.Close()
+
+	if a := NewAnimation(fh, &Password{}, 0); a != nil {
+		t.Errorf("Expected no animation for a regular file")
+	}
+}
@@ -1,10 +1,9 @@
-package main
+package day06
 
 import (
-	"bufio"
-	"fmt"
-	"os"
 	"strings"
+
+	"github.com/kirsle/goadvent2016/advent"
 )
 
 // Type Position keeps track of most frequently seen letters in a given position.
@@ -18,18 +17,19 @@ type Code struct {
 	Positions []*Position
 }
 
-func main() {
-	if len(os.Args) < 2 {
-		fmt.Println("Usage: main.go <input file>")
-		os.Exit(1)
-	}
+func init() {
+	advent.Register(6, advent.SolverFunc(Solve))
+}
 
+// Solve recovers the message from the repetition code.
+func Solve(part int, in *advent.Input) (advent.Result, error) {
 	// The eventual code we're trying to crack.
 	code := &Code{}
 
-	// Get the input strings.
-	inputs := ReadInputFile(os.Args[1])
-	for _, input := range inputs {
+	// Read the input strings.
+	for in.Scan() {
+		input := in.Text()
+
 		// Initialize the length of the code.
 		for len(code.Positions) < len(input) {
 			code.Positions = append(code.Positions, NewPosition())
@@ -41,9 +41,15 @@ func main() {
 		}
 	}
 
+	if err := in.Err(); err != nil {
+		return advent.Result{}, err
+	}
+
 	// Get the most/least frequent symbols (Part 1 & 2 of the puzzle)
-	fmt.Printf("The most likely code is: %s\n", code.MostLikely())
-	fmt.Printf("The least likely is: %s\n", code.LeastLikely())
+	if part == 1 {
+		return advent.Answer(code.MostLikely())
+	}
+	return advent.Answer(code.LeastLikely())
 }
 
 // NewPosition initializes a new position object.
@@ -110,30 +116,3 @@ func (c *Code) LeastLikely() string {
 	}
 	return strings.Join(result, "")
 }
-
-// ReadInputFile produces the list of strings from the file.
-func ReadInputFile(filename string) []string {
-	fh, err := os.Open(filename)
-	if err != nil {
-		panic(err)
-	}
-	defer fh.Close()
-
-	// Lines read.
-	parsed := []string{}
-
-	scanner := bufio.NewScanner(fh)
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if len(line) == 0 {
-			continue
-		}
-		parsed = append(parsed, line)
-	}
-
-	if err = scanner.Err(); err != nil {
-		panic(err)
-	}
-
-	return parsed
-}
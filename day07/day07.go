@@ -1,13 +1,17 @@
-package main
+package day07
 
 import (
-	"bufio"
+	"errors"
 	"fmt"
-	"os"
 	"regexp"
 	"strings"
+
+	"github.com/kirsle/goadvent2016/advent"
 )
 
+// For debug output: export ADVENT_LOG=day07=debug
+var logger = advent.NewLogger("day07")
+
 // Type Address contains the parts of an IPv7 address.
 type Address struct {
 	Address  string   // The original string version of the address
@@ -38,14 +42,17 @@ func SlidingWindow(input string, size int, cmp func(string) bool) string {
 	return ""
 }
 
-func main() {
-	if len(os.Args) < 2 {
-		fmt.Println("Usage: main.go <input file>")
-		os.Exit(1)
-	}
+func init() {
+	advent.Register(7, advent.SolverFunc(Solve))
+}
 
+// Solve counts the addresses that support TLS (part 1) or SSL (part 2).
+func Solve(part int, in *advent.Input) (advent.Result, error) {
 	// Get the inputs.
-	addresses := ParseAddresses(ReadFile(os.Args[1]))
+	addresses, err := ParseAddresses(in)
+	if err != nil {
+		return advent.Result{}, err
+	}
 
 	// Count the ones that support TLS and SSL.
 	var supportsTLS int
@@ -54,17 +61,19 @@ func main() {
 	// Scan the addresses.
 	for _, addr := range addresses {
 		if addr.SupportsTLS() {
-			Debug("%s supports TLS\n", addr.Address)
+			logger.Debug("%s supports TLS\n", addr.Address)
 			supportsTLS++
 		}
 		if addr.SupportsSSL() {
-			Debug("%s supports SSL\n", addr.Address)
+			logger.Debug("%s supports SSL\n", addr.Address)
 			supportsSSL++
 		}
 	}
 
-	fmt.Printf("%d addresses support TLS.\n", supportsTLS)
-	fmt.Printf("%d addresses support SSL.\n", supportsSSL)
+	if part == 1 {
+		return advent.Answer(supportsTLS)
+	}
+	return advent.Answer(supportsSSL)
 }
 
 // NewAddress creates a new address object.
@@ -172,63 +181,49 @@ func FindAbaBab(inputs []string) map[string]bool {
 }
 
 // ParseAddresses parses address lines into Address objects.
-func ParseAddresses(input []string) []Address {
+func ParseAddresses(in *advent.Input) ([]Address, error) {
 	result := []Address{}
 
-	for _, line := range input {
-		addr := NewAddress(line)
-
-		// Look for bracketed sets.
-		for strings.Index(line, "[") > -1 {
-			match := BracketRegexp.FindStringSubmatch(line)
-			if len(match) == 0 {
-				fmt.Printf("Regexp fail! %s has a bracket but the regexp didn't match it; skipping\n", line)
-				break
+	for in.Scan() {
+		addr, err := ParseAddress(in.Text())
+		if err != nil {
+			if err = in.Skip(in.Wrap(err)); err != nil {
+				return nil, err
 			}
-
-			segment := match[1]
-			addr.AddHypernet(segment)
-
-			// Remove this bracketed segment once done with it.
-			line = strings.Replace(line, fmt.Sprintf(`[%s]`, segment), "|", 1)
-		}
-
-		// Retrieve the supernet segments.
-		for _, supernet := range strings.Split(line, "|") {
-			addr.AddSupernet(supernet)
+			continue
 		}
 
 		result = append(result, addr)
 	}
 
-	return result
+	return result, in.Err()
 }
 
-// ReadFile reads lines from the input file.
-func ReadFile(filename string) []string {
-	fh, err := os.Open(filename)
-	if err != nil {
-		panic(err)
-	}
-
-	var lines []string
-
-	scanner := bufio.NewScanner(fh)
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if len(line) == 0 {
-			continue
+// ParseAddress parses an address line into an Address object.
+func ParseAddress(line string) (Address, error) {
+	addr := NewAddress(line)
+
+	// Look for bracketed sets.
+	for strings.Index(line, "[") > -1 {
+		match := BracketRegexp.FindStringSubmatch(line)
+		if len(match) == 0 {
+			return addr, &advent.ParseError{
+				Token: addr.Address,
+				Err:   errors.New("Has a bracket but the regexp didn't match it"),
+			}
 		}
 
-		lines = append(lines, line)
-	}
+		segment := match[1]
+		addr.AddHypernet(segment)
 
-	return lines
-}
+		// Remove this bracketed segment once done with it.
+		line = strings.Replace(line, fmt.Sprintf(`[%s]`, segment), "|", 1)
+	}
 
-// Debug prints a debug line when $DEBUG is true.
-func Debug(tmpl string, a ...interface{}) {
-	if os.Getenv("DEBUG") != "" {
-		fmt.Printf(tmpl, a...)
+	// Retrieve the supernet segments.
+	for _, supernet := range strings.Split(line, "|") {
+		addr.AddSupernet(supernet)
 	}
+
+	return addr, nil
 }
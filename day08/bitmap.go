@@ -0,0 +1,208 @@
+package day08
+
+import (
+	"math/bits"
+
+	"github.com/kirsle/goadvent2016/advent/grid"
+)
+
+// Type Pixels is how a screen stores its pixels. Bitmap is the fast one, and a
+// grid.Dense[bool] also works.
+type Pixels interface {
+	grid.Grid[bool]
+	Width() int
+	Height() int
+	Fill(min, max grid.Point, value bool)
+	Each(fn func(p grid.Point, value bool))
+	Count(match func(bool) bool) int
+	RotateRow(y, n int) error
+	RotateColumn(x, n int) error
+}
+
+// A grid.Dense[bool] works as the pixels of a screen too.
+var _ Pixels = (*grid.Dense[bool])(nil)
+
+// Type Bitmap stores the pixels as one bitset per row.
+//
+// Each row remembers how far it's been rotated instead of moving its bits, so
+// rotating a row takes the same time no matter how long the row is or how far
+// it's rotated. Rotating a column has to touch every row, but only once, and
+// the rows are packed together so that's quick too.
+type Bitmap struct {
+	width, height int
+	stride        int      // The number of words in a row
+	words         []uint64 // The bits of every row, one row after another
+	offsets       []int    // How far each row has been rotated
+	column        []uint64 // Scratch space for rotating a column, as 0s and 1s
+	places        []int    // Scratch space for where a column's bits are
+}
+
+// NewBitmap creates a bitmap with all the pixels turned off.
+func NewBitmap(width, height int) *Bitmap {
+	stride := (width + 63) / 64
+	return &Bitmap{
+		width:   width,
+		height:  height,
+		stride:  stride,
+		words:   make([]uint64, stride*height),
+		offsets: make([]int, height),
+		column:  make([]uint64, height),
+		places:  make([]int, height),
+	}
+}
+
+// Width returns the width of the bitmap.
+func (b *Bitmap) Width() int {
+	return b.width
+}
+
+// Height returns the height of the bitmap.
+func (b *Bitmap) Height() int {
+	return b.height
+}
+
+// InBounds tells whether a point is on the bitmap.
+func (b *Bitmap) InBounds(p grid.Point) bool {
+	return p.X >= 0 && p.Y >= 0 && p.X < b.width && p.Y < b.height
+}
+
+// Bounds returns the top left and bottom right corners of the bitmap.
+func (b *Bitmap) Bounds() (grid.Point, grid.Point) {
+	return grid.Pt(0, 0), grid.Pt(b.width-1, b.height-1)
+}
+
+// place finds which bit of the words a pixel is stored in. The pixel at x is
+// stored in bit (x - offset) mod width of its row. The point must be on the
+// bitmap.
+func (b *Bitmap) place(x, y int) int {
+	i := x - b.offsets[y]
+	if i < 0 {
+		i += b.width
+	}
+	return y*b.stride*64 + i
+}
+
+// get returns 1 if the pixel stored at a place is lit, or 0 if it's dark.
+func (b *Bitmap) get(place int) uint64 {
+	return b.words[place>>6] >> uint(place&63) & 1
+}
+
+// set turns the pixel stored at a place on (1) or off (0).
+func (b *Bitmap) set(place int, value uint64) {
+	word, shift := &b.words[place>>6], uint(place&63)
+	*word = *word&^(1<<shift) | value<<shift
+}
+
+// Get tells whether a pixel is lit. Pixels that are out of bounds are dark.
+func (b *Bitmap) Get(p grid.Point) bool {
+	if !b.InBounds(p) {
+		return false
+	}
+	return b.get(b.place(p.X, p.Y)) == 1
+}
+
+// Set turns a pixel on or off.
+func (b *Bitmap) Set(p grid.Point, lit bool) error {
+	if !b.InBounds(p) {
+		return grid.OutOfBoundsError{Point: p}
+	}
+
+	var value uint64
+	if lit {
+		value = 1
+	}
+	b.set(b.place(p.X, p.Y), value)
+	return nil
+}
+
+// Fill turns on or off every pixel in the rectangle between two corners.
+func (b *Bitmap) Fill(min, max grid.Point, lit bool) {
+	for y := min.Y; y <= max.Y; y++ {
+		for x := min.X; x <= max.X; x++ {
+			b.Set(grid.Pt(x, y), lit)
+		}
+	}
+}
+
+// Each calls a function for every pixel, row by row.
+func (b *Bitmap) Each(fn func(p grid.Point, lit bool)) {
+	for y := 0; y < b.height; y++ {
+		for x := 0; x < b.width; x++ {
+			p := grid.Pt(x, y)
+			fn(p, b.Get(p))
+		}
+	}
+}
+
+// Count counts the pixels that match. The lit pixels are counted a whole word
+// at a time.
+func (b *Bitmap) Count(match func(bool) bool) int {
+	lit := 0
+	for _, word := range b.words {
+		lit += bits.OnesCount64(word)
+	}
+
+	count := 0
+	if match(true) {
+		count += lit
+	}
+	if match(false) {
+		count += b.width*b.height - lit
+	}
+	return count
+}
+
+// RotateRow shifts a row to the right by n pixels, wrapping around. A negative
+// n shifts to the left.
+func (b *Bitmap) RotateRow(y, n int) error {
+	if y < 0 || y >= b.height {
+		return grid.OutOfBoundsError{Point: grid.Pt(0, y)}
+	}
+
+	b.offsets[y] = mod(b.offsets[y]+n, b.width)
+	return nil
+}
+
+// RotateColumn shifts a column down by n pixels, wrapping around. A negative n
+// shifts it up.
+func (b *Bitmap) RotateColumn(x, n int) error {
+	if x < 0 || x >= b.width {
+		return grid.OutOfBoundsError{Point: grid.Pt(x, 0)}
+	}
+
+	n = mod(n, b.height)
+	if n == 0 {
+		return nil
+	}
+
+	// Find where each of the column's pixels is stored and read them out,
+	// then write each one back n rows further down.
+	// The slices are copied into locals so they aren't loaded again after
+	// every write, which keeps this loop about as fast as a grid.Dense.
+	words, places, column := b.words, b.places, b.column
+	for y := range places {
+		places[y] = b.place(x, y)
+		column[y] = words[places[y]>>6] >> uint(places[y]&63) & 1
+	}
+	for y, value := range column {
+		to := y + n
+		if to >= len(places) {
+			to -= len(places)
+		}
+		word, shift := places[to]>>6, uint(places[to]&63)
+		words[word] = words[word]&^(1<<shift) | value<<shift
+	}
+	return nil
+}
+
+// mod is the modulo that's never negative, for wrapping around.
+func mod(a, n int) int {
+	if n == 0 {
+		return 0
+	}
+	a %= n
+	if a < 0 {
+		a += n
+	}
+	return a
+}
@@ -0,0 +1,374 @@
+package day08
+
+import (
+	"flag"
+	"fmt"
+	"regexp"
+
+	"github.com/kirsle/goadvent2016/advent"
+	"github.com/kirsle/goadvent2016/advent/grid"
+)
+
+// For debug output: export ADVENT_LOG=day08=debug
+var logger = advent.NewLogger("day08")
+
+// The size of the screen in the puzzle.
+const (
+	ScreenWidth  = 50
+	ScreenHeight = 6
+)
+
+// Type Screen represents our LCD screen, which is 50x6 in the puzzle. The
+// pixels are a grid where a true value means the pixel is lit.
+type Screen struct {
+	Pixels Pixels
+}
+
+// Type Solver runs the screen instructions, with options from the command
+// line.
+type Solver struct {
+	Size  string // The screen size, like "50x6"
+	Scale int    // How big the pixels are drawn when exporting an image
+}
+
+func init() {
+	advent.Register(8, &Solver{})
+}
+
+// Flags adds the day 8 options to the `advent run` and `advent export`
+// commands.
+func (s *Solver) Flags(fs *flag.FlagSet) {
+	fs.StringVar(&s.Size, "size", fmt.Sprintf("%dx%d", ScreenWidth, ScreenHeight), "Day 8: screen size, like 7x3 for the example")
+	fs.IntVar(&s.Scale, "scale", DefaultScale, "Day 8: size of each pixel in exported images")
+}
+
+// Solve runs the screen instructions. Part 1 counts the lit pixels and part 2
+// returns the final screen so its message can be read.
+func (s *Solver) Solve(part int, in *advent.Input) (advent.Result, error) {
+	result := advent.Result{}
+	screen, err := s.Run(in, nil)
+	if err != nil {
+		return result, err
+	}
+
+	// The final screen spells out the answer to part 2. If it can't be read,
+	// the answer is the screen itself so it can be read by eye.
+	if part == 2 {
+		text, unknown, err := screen.Read()
+		if err != nil {
+			result.Diagnose("%s", err)
+			result.Answer = screen.String()
+			return result, nil
+		}
+
+		for _, i := range unknown {
+			result.Diagnose("Unrecognized glyph %d:\n%s", i, screen.Glyph(i))
+		}
+		if len(unknown) > 0 {
+			result.Diagnose("Screen:\n%s", screen)
+		}
+		result.Answer = text
+		return result, nil
+	}
+
+	// Count the lit pixels.
+	result.Answer = screen.LitCount()
+	return result, nil
+}
+
+// Run creates a screen of the Solver's size and runs the instructions on it.
+// The after function, if given, is called with the screen after each
+// instruction.
+func (s *Solver) Run(in *advent.Input, after func(*Screen)) (*Screen, error) {
+	width, height, err := ParseSize(s.Size)
+	if err != nil {
+		return nil, err
+	}
+	screen := NewScreen(width, height)
+
+	for in.Scan() {
+		err := screen.ProcessInstruction(in.Text())
+		if err != nil {
+			if err = in.Skip(in.Wrap(err)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		// When debugging, print the screen after every update.
+		if logger.Enabled(advent.LevelDebug) {
+			logger.Debug("Screen:\n%s", screen)
+		}
+		if after != nil {
+			after(screen)
+		}
+	}
+
+	return screen, in.Err()
+}
+
+// ParseSize parses a screen size like "50x6". An empty size is the size of the
+// screen in the puzzle.
+func ParseSize(size string) (int, int, error) {
+	if size == "" {
+		return ScreenWidth, ScreenHeight, nil
+	}
+
+	match := SizeRegexp.FindStringSubmatch(size)
+	if len(match) == 0 {
+		return 0, 0, fmt.Errorf("Invalid screen size %q: should be like 50x6", size)
+	}
+
+	values, err := advent.StringsToInts(match[1:])
+	if err != nil {
+		return 0, 0, err
+	}
+	if values[0] == 0 || values[1] == 0 {
+		return 0, 0, fmt.Errorf("Invalid screen size %q: it can't be empty", size)
+	}
+	return values[0], values[1], nil
+}
+
+// SizeRegexp matches a screen size like "50x6".
+var SizeRegexp = regexp.MustCompile(`^(\d+)x(\d+)$`)
+
+// NewScreen creates a new LCD screen with all the pixels turned off.
+func NewScreen(width, height int) *Screen {
+	return &Screen{
+		Pixels: NewBitmap(width, height),
+	}
+}
+
+// Light turns on a pixel at a given coordinate.
+func (s *Screen) Light(x, y int) error {
+	return s.Pixels.Set(grid.Pt(x, y), true)
+}
+
+// Dark turns off a pixel at a given coordinate.
+func (s *Screen) Dark(x, y int) error {
+	return s.Pixels.Set(grid.Pt(x, y), false)
+}
+
+// IsLit tells whether a pixel at a given coordinate is lit.
+func (s *Screen) IsLit(x, y int) (bool, error) {
+	if err := s.BoundsCheck(x, y); err != nil {
+		return false, err
+	}
+	return s.Pixels.Get(grid.Pt(x, y)), nil
+}
+
+// LitCount counts the number of lit pixels.
+func (s *Screen) LitCount() int {
+	return s.Pixels.Count(func(lit bool) bool {
+		return lit
+	})
+}
+
+// BoundsCheck checks whether an X and Y coordinate is valid.
+func (s *Screen) BoundsCheck(x, y int) error {
+	p := grid.Pt(x, y)
+	if !s.Pixels.InBounds(p) {
+		return grid.OutOfBoundsError{Point: p}
+	}
+	return nil
+}
+
+// String returns what the screen looks like in ASCII art.
+func (s *Screen) String() string {
+	return grid.Render[bool](s.Pixels, func(lit bool) rune {
+		if lit {
+			return '#'
+		}
+		return '.'
+	})
+}
+
+// Print shows what the screen looks like in ASCII art.
+func (s *Screen) Print() {
+	fmt.Printf("%s\n\n", s.String())
+}
+
+// Type Instruction is a kind of screen instruction: a regexp that matches it,
+// and a handler that carries it out with the numbers from the regexp.
+type Instruction struct {
+	Name    string
+	Regexp  *regexp.Regexp
+	Handler func(s *Screen, args []int) error
+}
+
+// Instructions is the dispatch table for the screen instructions. Regions are
+// written like "3x2 at 1,0" for a 3 pixel wide and 2 pixel tall area with its
+// top left corner at (1,0).
+var Instructions = []Instruction{
+	{
+		Name:   "rect",
+		Regexp: regexp.MustCompile(`^rect (\d+)x(\d+)$`),
+		Handler: func(s *Screen, args []int) error {
+			return s.Rect(0, 0, args[0], args[1], true)
+		},
+	},
+	{
+		Name:   "rect at",
+		Regexp: regexp.MustCompile(`^rect (\d+)x(\d+) at (\d+),(\d+)$`),
+		Handler: func(s *Screen, args []int) error {
+			return s.Rect(args[2], args[3], args[0], args[1], true)
+		},
+	},
+	{
+		Name:   "fill",
+		Regexp: regexp.MustCompile(`^fill$`),
+		Handler: func(s *Screen, args []int) error {
+			return s.Rect(0, 0, s.Pixels.Width(), s.Pixels.Height(), true)
+		},
+	},
+	{
+		Name:   "clear",
+		Regexp: regexp.MustCompile(`^clear$`),
+		Handler: func(s *Screen, args []int) error {
+			return s.Rect(0, 0, s.Pixels.Width(), s.Pixels.Height(), false)
+		},
+	},
+	{
+		Name:   "clear at",
+		Regexp: regexp.MustCompile(`^clear (\d+)x(\d+) at (\d+),(\d+)$`),
+		Handler: func(s *Screen, args []int) error {
+			return s.Rect(args[2], args[3], args[0], args[1], false)
+		},
+	},
+	{
+		Name:   "invert",
+		Regexp: regexp.MustCompile(`^invert$`),
+		Handler: func(s *Screen, args []int) error {
+			return s.Invert(0, 0, s.Pixels.Width(), s.Pixels.Height())
+		},
+	},
+	{
+		Name:   "invert at",
+		Regexp: regexp.MustCompile(`^invert (\d+)x(\d+) at (\d+),(\d+)$`),
+		Handler: func(s *Screen, args []int) error {
+			return s.Invert(args[2], args[3], args[0], args[1])
+		},
+	},
+	{
+		Name:   "rotate row",
+		Regexp: regexp.MustCompile(`^rotate row y=(\d+) by (-?\d+)$`),
+		Handler: func(s *Screen, args []int) error {
+			return s.Pixels.RotateRow(args[0], args[1])
+		},
+	},
+	{
+		Name:   "rotate column",
+		Regexp: regexp.MustCompile(`^rotate column x=(\d+) by (-?\d+)$`),
+		Handler: func(s *Screen, args []int) error {
+			return s.Pixels.RotateColumn(args[0], args[1])
+		},
+	},
+	{
+		Name:   "swap row",
+		Regexp: regexp.MustCompile(`^swap row y=(\d+) with y=(\d+)$`),
+		Handler: func(s *Screen, args []int) error {
+			return s.SwapRows(args[0], args[1])
+		},
+	},
+	{
+		Name:   "swap column",
+		Regexp: regexp.MustCompile(`^swap column x=(\d+) with x=(\d+)$`),
+		Handler: func(s *Screen, args []int) error {
+			return s.SwapColumns(args[0], args[1])
+		},
+	},
+}
+
+// ProcessInstruction parses and executes a pixel manipulation function.
+func (s *Screen) ProcessInstruction(input string) error {
+	logger.Debug("INSTRUCTION: %s", input)
+
+	// Find the handler for the type of instruction we're dealing with.
+	for _, instruction := range Instructions {
+		match := instruction.Regexp.FindStringSubmatch(input)
+		if len(match) == 0 {
+			continue
+		}
+
+		// Turn the regexp matches into ints.
+		args, err := advent.StringsToInts(match[1:])
+		if err != nil {
+			return err
+		}
+		return instruction.Handler(s, args)
+	}
+	return fmt.Errorf("Invalid instruction: %s", input)
+}
+
+// Rect turns on (or off) every pixel in a rectangle.
+func (s *Screen) Rect(x, y, width, height int, lit bool) error {
+	if width == 0 || height == 0 {
+		return nil
+	}
+	if err := s.checkRegion(x, y, width, height); err != nil {
+		return err
+	}
+
+	s.Pixels.Fill(grid.Pt(x, y), grid.Pt(x+width-1, y+height-1), lit)
+	return nil
+}
+
+// Invert flips every pixel in a rectangle.
+func (s *Screen) Invert(x, y, width, height int) error {
+	if width == 0 || height == 0 {
+		return nil
+	}
+	if err := s.checkRegion(x, y, width, height); err != nil {
+		return err
+	}
+
+	for py := y; py < y+height; py++ {
+		for px := x; px < x+width; px++ {
+			p := grid.Pt(px, py)
+			s.Pixels.Set(p, !s.Pixels.Get(p))
+		}
+	}
+	return nil
+}
+
+// SwapRows swaps the pixels of two rows.
+func (s *Screen) SwapRows(a, b int) error {
+	if err := s.BoundsCheck(0, a); err != nil {
+		return err
+	}
+	if err := s.BoundsCheck(0, b); err != nil {
+		return err
+	}
+
+	for x := 0; x < s.Pixels.Width(); x++ {
+		pixelA, pixelB := s.Pixels.Get(grid.Pt(x, a)), s.Pixels.Get(grid.Pt(x, b))
+		s.Pixels.Set(grid.Pt(x, a), pixelB)
+		s.Pixels.Set(grid.Pt(x, b), pixelA)
+	}
+	return nil
+}
+
+// SwapColumns swaps the pixels of two columns.
+func (s *Screen) SwapColumns(a, b int) error {
+	if err := s.BoundsCheck(a, 0); err != nil {
+		return err
+	}
+	if err := s.BoundsCheck(b, 0); err != nil {
+		return err
+	}
+
+	for y := 0; y < s.Pixels.Height(); y++ {
+		pixelA, pixelB := s.Pixels.Get(grid.Pt(a, y)), s.Pixels.Get(grid.Pt(b, y))
+		s.Pixels.Set(grid.Pt(a, y), pixelB)
+		s.Pixels.Set(grid.Pt(b, y), pixelA)
+	}
+	return nil
+}
+
+// checkRegion checks that both corners of a rectangle are on the screen.
+func (s *Screen) checkRegion(x, y, width, height int) error {
+	if err := s.BoundsCheck(x, y); err != nil {
+		return err
+	}
+	return s.BoundsCheck(x+width-1, y+height-1)
+}
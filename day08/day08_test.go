@@ -0,0 +1,442 @@
+package day08
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image/color"
+	"image/gif"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kirsle/goadvent2016/advent"
+	"github.com/kirsle/goadvent2016/advent/grid"
+)
+
+// litPoints lists the lit pixels of a screen, row by row.
+func litPoints(s *Screen) []grid.Point {
+	result := []grid.Point{}
+	s.Pixels.Each(func(p grid.Point, lit bool) {
+		if lit {
+			result = append(result, p)
+		}
+	})
+	return result
+}
+
+// allPointsExcept lists every pixel of the puzzle's screen, row by row, but
+// leaves out some of them.
+func allPointsExcept(except ...grid.Point) []grid.Point {
+	result := []grid.Point{}
+	for y := 0; y < ScreenHeight; y++ {
+		for x := 0; x < ScreenWidth; x++ {
+			p := grid.Pt(x, y)
+			skip := false
+			for _, e := range except {
+				skip = skip || e == p
+			}
+			if !skip {
+				result = append(result, p)
+			}
+		}
+	}
+	return result
+}
+
+func TestInstructions(t *testing.T) {
+	tests := []struct {
+		setup       []string // Instructions to run first
+		instruction string
+		expect      []grid.Point // The lit pixels after
+		shouldError bool
+	}{
+		{
+			instruction: "rect 2x1",
+			expect:      []grid.Point{grid.Pt(0, 0), grid.Pt(1, 0)},
+		},
+		{
+			instruction: "rect 2x2 at 3,1",
+			expect:      []grid.Point{grid.Pt(3, 1), grid.Pt(4, 1), grid.Pt(3, 2), grid.Pt(4, 2)},
+		},
+		{
+			instruction: "rect 3x1 at 48,0",
+			shouldError: true,
+		},
+		{
+			setup:       []string{"rect 1x1"},
+			instruction: "fill",
+			expect:      allPointsExcept(),
+		},
+		{
+			setup:       []string{"fill"},
+			instruction: "clear",
+			expect:      []grid.Point{},
+		},
+		{
+			setup:       []string{"rect 3x1"},
+			instruction: "clear 1x1 at 1,0",
+			expect:      []grid.Point{grid.Pt(0, 0), grid.Pt(2, 0)},
+		},
+		{
+			setup:       []string{"rect 1x1", "rect 1x1 at 49,5"},
+			instruction: "invert",
+			expect:      allPointsExcept(grid.Pt(0, 0), grid.Pt(49, 5)),
+		},
+		{
+			setup:       []string{"rect 1x1"},
+			instruction: "invert 2x2 at 0,0",
+			expect:      []grid.Point{grid.Pt(1, 0), grid.Pt(0, 1), grid.Pt(1, 1)},
+		},
+		{
+			setup:       []string{"rect 1x1"},
+			instruction: "rotate row y=0 by 3",
+			expect:      []grid.Point{grid.Pt(3, 0)},
+		},
+		{
+			setup:       []string{"rect 1x1"},
+			instruction: "rotate row y=0 by -1",
+			expect:      []grid.Point{grid.Pt(ScreenWidth-1, 0)},
+		},
+		{
+			setup:       []string{"rect 1x1"},
+			instruction: "rotate column x=0 by -2",
+			expect:      []grid.Point{grid.Pt(0, ScreenHeight-2)},
+		},
+		{
+			instruction: "rotate column x=50 by 1",
+			shouldError: true,
+		},
+		{
+			setup:       []string{"rect 2x1"},
+			instruction: "swap row y=0 with y=4",
+			expect:      []grid.Point{grid.Pt(0, 4), grid.Pt(1, 4)},
+		},
+		{
+			setup:       []string{"rect 1x2"},
+			instruction: "swap column x=0 with x=7",
+			expect:      []grid.Point{grid.Pt(7, 0), grid.Pt(7, 1)},
+		},
+		{
+			instruction: "draw a smiley face",
+			shouldError: true,
+		},
+	}
+
+	for _, test := range tests {
+		screen := NewScreen(ScreenWidth, ScreenHeight)
+		for _, setup := range test.setup {
+			if err := screen.ProcessInstruction(setup); err != nil {
+				t.Fatalf("Unexpected error from %q: %s", setup, err)
+			}
+		}
+
+		err := screen.ProcessInstruction(test.instruction)
+		if test.shouldError {
+			if err == nil {
+				t.Errorf("Expected an error from %q", test.instruction)
+			}
+			continue
+		} else if err != nil {
+			t.Errorf("Unexpected error from %q: %s", test.instruction, err)
+			continue
+		}
+
+		if result := litPoints(screen); !reflect.DeepEqual(result, test.expect) {
+			t.Errorf("Output assertion error for %q: expected %v, got %v", test.instruction, test.expect, result)
+		}
+	}
+}
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		size          string
+		width, height int
+		shouldError   bool
+	}{
+		{"50x6", 50, 6, false},
+		{"7x3", 7, 3, false},
+		{"", ScreenWidth, ScreenHeight, false},
+		{"7", 0, 0, true},
+		{"0x3", 0, 0, true},
+		{"-7x3", 0, 0, true},
+	}
+
+	for _, test := range tests {
+		width, height, err := ParseSize(test.size)
+		if test.shouldError {
+			if err == nil {
+				t.Errorf("ParseSize(%q) assertion error: expected an error", test.size)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseSize(%q) unexpected error: %s", test.size, err)
+			continue
+		}
+		if width != test.width || height != test.height {
+			t.Errorf("ParseSize(%q) assertion error: expected %dx%d, got %dx%d",
+				test.size, test.width, test.height, width, height)
+		}
+	}
+}
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		rows    []string // The screen, which is 6 pixels tall
+		expect  string
+		unknown []int
+	}{
+		{
+			rows: []string{
+				"#..#.###..",
+				"#..#..#...",
+				"####..#...",
+				"#..#..#...",
+				"#..#..#...",
+				"#..#.###..",
+			},
+			expect:  "H?",
+			unknown: []int{1},
+		},
+		{
+			rows: []string{
+				".....#...#.##..",
+				".....#...##..#.",
+				"......#.#.#..#.",
+				".......#..#..#.",
+				".......#..#..#.",
+				".......#...##..",
+			},
+			expect:  "YO",
+			unknown: []int{},
+		},
+	}
+
+	for _, test := range tests {
+		screen := NewScreen(len(test.rows[0]), len(test.rows))
+		for y, row := range test.rows {
+			for x, pixel := range row {
+				if pixel == '#' {
+					screen.Light(x, y)
+				}
+			}
+		}
+
+		text, unknown, err := screen.Read()
+		if err != nil {
+			t.Errorf("Read(%s) unexpected error: %s", test.expect, err)
+			continue
+		}
+		if text != test.expect || !reflect.DeepEqual(unknown, test.unknown) {
+			t.Errorf("Read assertion error: expected %q with unknown glyphs %v, got %q with %v",
+				test.expect, test.unknown, text, unknown)
+		}
+	}
+
+	if _, _, err := NewScreen(7, 3).Read(); err == nil {
+		t.Errorf("Read assertion error: expected an error for a 7x3 screen")
+	}
+}
+
+func TestImage(t *testing.T) {
+	screen := NewScreen(7, 3)
+	screen.Light(1, 2)
+
+	img := screen.Image(4)
+	if bounds := img.Bounds(); bounds.Dx() != 28 || bounds.Dy() != 12 {
+		t.Errorf("Image size assertion error: expected 28x12, got %dx%d", bounds.Dx(), bounds.Dy())
+	}
+
+	tests := []struct {
+		x, y   int
+		expect color.Color
+	}{
+		{4, 8, LitColor},         // Top left of the lit pixel
+		{6, 10, LitColor},        // Inside the lit pixel
+		{7, 11, BackgroundColor}, // The gap after it
+		{0, 0, DarkColor},
+	}
+	for _, test := range tests {
+		if got := img.At(test.x, test.y); got != test.expect {
+			t.Errorf("Image(%d,%d) assertion error: expected %v, got %v", test.x, test.y, test.expect, got)
+		}
+	}
+
+	recording := NewRecording(screen, 1)
+	recording.Record(screen)
+	var buf bytes.Buffer
+	if err := recording.Encode(&buf); err != nil {
+		t.Errorf("Encode unexpected error: %s", err)
+	}
+	decoded, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatalf("DecodeAll unexpected error: %s", err)
+	}
+	if len(decoded.Image) != 2 || decoded.Delay[1] != LastFrameDelay {
+		t.Errorf("Recording assertion error: expected 2 frames ending with delay %d, got %d frames with delays %v",
+			LastFrameDelay, len(decoded.Image), decoded.Delay)
+	}
+}
+
+func TestSynthesize(t *testing.T) {
+	pictures := [][]string{
+		{"#"},
+		{"...", "..."},
+		{"#.#", ".##"},
+		{"..##...", "#.....#", "###.###"},
+	}
+
+	// Add some random pictures too.
+	random := rand.New(rand.NewSource(8))
+	for i := 0; i < 20; i++ {
+		rows := []string{}
+		for y := 0; y < ScreenHeight; y++ {
+			var row strings.Builder
+			for x := 0; x < ScreenWidth; x++ {
+				row.WriteByte(".#"[random.Intn(2)])
+			}
+			rows = append(rows, row.String())
+		}
+		pictures = append(pictures, rows)
+	}
+
+	for _, rows := range pictures {
+		picture := NewScreen(len(rows[0]), len(rows))
+		for y, row := range rows {
+			for x, pixel := range row {
+				if pixel == '#' {
+					picture.Light(x, y)
+				}
+			}
+		}
+
+		script := Synthesize(picture)
+		for _, instruction := range script {
+			if !strings.HasPrefix(instruction, "rect ") && !strings.HasPrefix(instruction, "rotate ") {
+				t.Errorf("Synthesize assertion error: expected only rect and rotate, got %q", instruction)
+			}
+		}
+		if err := Replay(script, picture); err != nil {
+			t.Errorf("Synthesize assertion error for:\n%s\n\n%s", picture, err)
+		}
+	}
+}
+
+func TestReadPicture(t *testing.T) {
+	// A blank line is a dark row, and blank lines at the end are ignored.
+	const picture = "#..\n\n..#\n\n\n"
+	in := advent.NewInput(context.Background(), "picture", strings.NewReader(picture))
+	screen, err := ReadPicture(in, 3, 3)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expected := []grid.Point{{X: 0, Y: 0}, {X: 2, Y: 2}}
+	if result := litPoints(screen); !reflect.DeepEqual(result, expected) {
+		t.Errorf("ReadPicture assertion error: expected %v, got %v", expected, result)
+	}
+
+	// Counting the blank row, this one is too tall.
+	in = advent.NewInput(context.Background(), "picture", strings.NewReader("#..\n\n..#"))
+	if _, err := ReadPicture(in, 3, 2); err == nil {
+		t.Errorf("Expected an error for a picture that's too tall")
+	}
+}
+
+// newDenseScreen creates a screen that stores its pixels in a grid.Dense, to
+// compare against the Bitmap.
+func newDenseScreen(width, height int) *Screen {
+	return &Screen{
+		Pixels: grid.NewDense[bool](width, height),
+	}
+}
+
+// randomScript makes a script of random instructions for a screen.
+func randomScript(random *rand.Rand, width, height, length, maxRotate int) []string {
+	script := []string{}
+	for i := 0; i < length; i++ {
+		var instruction string
+		switch random.Intn(5) {
+		case 0:
+			instruction = fmt.Sprintf("rect %dx%d", random.Intn(width)+1, random.Intn(height)+1)
+		case 1:
+			instruction = fmt.Sprintf("rotate row y=%d by %d", random.Intn(height), random.Intn(2*maxRotate)-maxRotate)
+		case 2:
+			instruction = fmt.Sprintf("rotate column x=%d by %d", random.Intn(width), random.Intn(2*maxRotate)-maxRotate)
+		case 3:
+			instruction = fmt.Sprintf("invert %dx%d at 0,0", random.Intn(width)+1, random.Intn(height)+1)
+		case 4:
+			instruction = fmt.Sprintf("swap row y=%d with y=%d", random.Intn(height), random.Intn(height))
+		}
+		script = append(script, instruction)
+	}
+	return script
+}
+
+func TestBitmap(t *testing.T) {
+	random := rand.New(rand.NewSource(23))
+	for i := 0; i < 20; i++ {
+		width, height := random.Intn(100)+1, random.Intn(10)+1
+		bitmap, dense := NewScreen(width, height), newDenseScreen(width, height)
+
+		for _, instruction := range randomScript(random, width, height, 100, 1000) {
+			errB, errD := bitmap.ProcessInstruction(instruction), dense.ProcessInstruction(instruction)
+			if (errB == nil) != (errD == nil) {
+				t.Fatalf("%q: Bitmap and Dense disagree about errors: %v and %v", instruction, errB, errD)
+			}
+			if bitmap.String() != dense.String() {
+				t.Fatalf("%q assertion error: expected\n%s\ngot\n%s", instruction, dense, bitmap)
+			}
+		}
+		if bitmap.LitCount() != dense.LitCount() {
+			t.Errorf("LitCount assertion error: expected %d, got %d", dense.LitCount(), bitmap.LitCount())
+		}
+	}
+}
+
+func BenchmarkRotate(b *testing.B) {
+	pixels := []struct {
+		name string
+		new  func(width, height int) Pixels
+	}{
+		{"Bitmap", func(width, height int) Pixels { return NewBitmap(width, height) }},
+		{"Dense", func(width, height int) Pixels { return grid.NewDense[bool](width, height) }},
+	}
+	sizes := []struct {
+		width, height, maxRotate int
+	}{
+		{ScreenWidth, ScreenHeight, 10},
+		{1000, 1000, 1000000},
+	}
+
+	for _, size := range sizes {
+		// Random rotations, by up to maxRotate either way.
+		random := rand.New(rand.NewSource(8))
+		amounts := []int{}
+		for i := 0; i < 1000; i++ {
+			amounts = append(amounts, random.Intn(2*size.maxRotate)-size.maxRotate)
+		}
+
+		for _, kind := range pixels {
+			p := kind.new(size.width, size.height)
+			p.Fill(grid.Pt(0, 0), grid.Pt(size.width/2, size.height/2), true)
+
+			name := fmt.Sprintf("%dx%d/%s", size.width, size.height, kind.name)
+			b.Run("Row/"+name, func(b *testing.B) {
+				for n := 0; n < b.N; n++ {
+					for i, amount := range amounts {
+						p.RotateRow(i%size.height, amount)
+					}
+				}
+			})
+			b.Run("Column/"+name, func(b *testing.B) {
+				for n := 0; n < b.N; n++ {
+					for i, amount := range amounts {
+						p.RotateColumn(i%size.width, amount)
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package day08
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"io"
+
+	"github.com/kirsle/goadvent2016/advent"
+)
+
+// DefaultScale is how many pixels across each pixel of the screen is drawn
+// with in an image.
+const DefaultScale = 8
+
+// How long each frame of an animation is shown, in 100ths of a second. The
+// last frame is held for longer so the final screen can be read.
+const (
+	FrameDelay     = 5
+	LastFrameDelay = 300
+)
+
+// The colors of the screen in an image: green LCD pixels on a black
+// background, with the dark pixels faintly visible.
+var (
+	BackgroundColor = color.RGBA{0x00, 0x00, 0x00, 0xff}
+	DarkColor       = color.RGBA{0x0a, 0x26, 0x0a, 0xff}
+	LitColor        = color.RGBA{0x33, 0xff, 0x33, 0xff}
+
+	Palette = color.Palette{BackgroundColor, DarkColor, LitColor}
+)
+
+// Image draws the screen as an image, with each pixel drawn as a square scale
+// pixels across. When the squares are big enough they have a gap between them,
+// like the pixels of a real LCD.
+func (s *Screen) Image(scale int) *image.Paletted {
+	if scale < 1 {
+		scale = 1
+	}
+
+	gap := 0
+	if scale >= 4 {
+		gap = 1
+	}
+
+	img := image.NewPaletted(image.Rect(0, 0, s.Pixels.Width()*scale, s.Pixels.Height()*scale), Palette)
+	for y := 0; y < s.Pixels.Height(); y++ {
+		for x := 0; x < s.Pixels.Width(); x++ {
+			var index uint8 = 1
+			if lit, _ := s.IsLit(x, y); lit {
+				index = 2
+			}
+
+			for dy := 0; dy < scale-gap; dy++ {
+				for dx := 0; dx < scale-gap; dx++ {
+					img.SetColorIndex(x*scale+dx, y*scale+dy, index)
+				}
+			}
+		}
+	}
+	return img
+}
+
+// Type Recording collects a frame of the screen after every instruction, to
+// be saved as an animated GIF.
+type Recording struct {
+	Scale int
+	GIF   gif.GIF
+}
+
+// NewRecording starts a recording, with the blank screen as its first frame.
+func NewRecording(screen *Screen, scale int) *Recording {
+	r := &Recording{
+		Scale: scale,
+	}
+	r.Record(screen)
+	return r
+}
+
+// Record adds a frame of what the screen looks like now.
+func (r *Recording) Record(screen *Screen) {
+	r.GIF.Image = append(r.GIF.Image, screen.Image(r.Scale))
+	r.GIF.Delay = append(r.GIF.Delay, FrameDelay)
+}
+
+// Encode writes the recording as an animated GIF.
+func (r *Recording) Encode(w io.Writer) error {
+	if len(r.GIF.Delay) > 0 {
+		r.GIF.Delay[len(r.GIF.Delay)-1] = LastFrameDelay
+	}
+	return gif.EncodeAll(w, &r.GIF)
+}
+
+// ExportFormats lists the formats day 8 can be exported to.
+func (s *Solver) ExportFormats() []string {
+	return []string{"png", "gif", "script"}
+}
+
+// Export runs the screen instructions and draws the screen: "png" draws the
+// final screen, and "gif" animates every instruction.
+//
+// The "script" format works the other way around: the input is a picture
+// drawn with `#` and `.`, and the output is the instructions that draw it.
+func (s *Solver) Export(w io.Writer, format string, in *advent.Input) error {
+	switch format {
+	case "script":
+		width, height, err := ParseSize(s.Size)
+		if err != nil {
+			return err
+		}
+		picture, err := ReadPicture(in, width, height)
+		if err != nil {
+			return err
+		}
+		return WriteScript(w, picture)
+	case "png":
+		screen, err := s.Run(in, nil)
+		if err != nil {
+			return err
+		}
+		return png.Encode(w, screen.Image(s.Scale))
+	case "gif":
+		width, height, err := ParseSize(s.Size)
+		if err != nil {
+			return err
+		}
+		recording := NewRecording(NewScreen(width, height), s.Scale)
+
+		if _, err := s.Run(in, recording.Record); err != nil {
+			return err
+		}
+		return recording.Encode(w)
+	}
+	return errors.New("Unknown export format: " + format)
+}
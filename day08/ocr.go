@@ -0,0 +1,107 @@
+package day08
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kirsle/goadvent2016/advent/grid"
+)
+
+// The size of a letter on the screen. Each letter is drawn in a cell 5 pixels
+// wide, which is usually 4 pixels for the letter and a blank column after it.
+const (
+	GlyphWidth  = 5
+	GlyphHeight = 6
+)
+
+// Unknown is the character used for a glyph that isn't in the Font.
+const Unknown = '?'
+
+// Font is the letters that the screen can show, in the font that Advent of
+// Code uses for its ASCII art answers. The rows are padded out to GlyphWidth
+// with dark pixels when they're looked up.
+var Font = map[rune][]string{
+	'A': {".##.", "#..#", "#..#", "####", "#..#", "#..#"},
+	'B': {"###.", "#..#", "###.", "#..#", "#..#", "###."},
+	'C': {".##.", "#..#", "#...", "#...", "#..#", ".##."},
+	'E': {"####", "#...", "###.", "#...", "#...", "####"},
+	'F': {"####", "#...", "###.", "#...", "#...", "#..."},
+	'G': {".##.", "#..#", "#...", "#.##", "#..#", ".###"},
+	'H': {"#..#", "#..#", "####", "#..#", "#..#", "#..#"},
+	'I': {".###", "..#.", "..#.", "..#.", "..#.", ".###"},
+	'J': {"..##", "...#", "...#", "...#", "#..#", ".##."},
+	'K': {"#..#", "#.#.", "##..", "#.#.", "#.#.", "#..#"},
+	'L': {"#...", "#...", "#...", "#...", "#...", "####"},
+	'O': {".##.", "#..#", "#..#", "#..#", "#..#", ".##."},
+	'P': {"###.", "#..#", "#..#", "###.", "#...", "#..."},
+	'R': {"###.", "#..#", "#..#", "###.", "#.#.", "#..#"},
+	'S': {".###", "#...", "#...", ".##.", "...#", "###."},
+	'U': {"#..#", "#..#", "#..#", "#..#", "#..#", ".##."},
+	'Y': {"#...#", "#...#", ".#.#.", "..#..", "..#..", "..#.."},
+	'Z': {"####", "...#", "..#.", ".#..", "#...", "####"},
+	' ': {"", "", "", "", "", ""},
+}
+
+// glyphs looks up a letter by its glyph, as drawn by Screen.Glyph.
+var glyphs = map[string]rune{}
+
+func init() {
+	for letter, rows := range Font {
+		padded := []string{}
+		for _, row := range rows {
+			padded = append(padded, row+strings.Repeat(".", GlyphWidth-len(row)))
+		}
+		glyphs[strings.Join(padded, "\n")] = letter
+	}
+}
+
+// Glyphs returns the number of letter cells across the screen.
+func (s *Screen) Glyphs() int {
+	return (s.Pixels.Width() + GlyphWidth - 1) / GlyphWidth
+}
+
+// Glyph draws the letter cell at an index, with `#` for the lit pixels and `.`
+// for the dark ones. A cell that runs off the right edge of the screen is
+// padded with dark pixels.
+func (s *Screen) Glyph(index int) string {
+	rows := []string{}
+	for y := 0; y < GlyphHeight && y < s.Pixels.Height(); y++ {
+		var row strings.Builder
+		for x := index * GlyphWidth; x < (index+1)*GlyphWidth; x++ {
+			if s.Pixels.Get(grid.Pt(x, y)) {
+				row.WriteRune('#')
+			} else {
+				row.WriteRune('.')
+			}
+		}
+		rows = append(rows, row.String())
+	}
+	return strings.Join(rows, "\n")
+}
+
+// Read reads the letters on the screen. A glyph that isn't in the Font is read
+// as the Unknown character, and its index is returned in the list of unknown
+// glyphs. Blank cells at the ends of the screen are trimmed off.
+//
+// It's an error to read a screen that isn't exactly one letter tall.
+func (s *Screen) Read() (string, []int, error) {
+	if s.Pixels.Height() != GlyphHeight {
+		return "", nil, fmt.Errorf("Can't read a screen %d pixels tall: letters are %d pixels tall",
+			s.Pixels.Height(), GlyphHeight)
+	}
+
+	var (
+		text    = []rune{}
+		unknown = []int{}
+	)
+	for i := 0; i < s.Glyphs(); i++ {
+		letter, ok := glyphs[s.Glyph(i)]
+		if !ok {
+			letter = Unknown
+			unknown = append(unknown, i)
+		}
+		text = append(text, letter)
+	}
+
+	return strings.TrimSpace(string(text)), unknown, nil
+}
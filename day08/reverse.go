@@ -0,0 +1,156 @@
+package day08
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/kirsle/goadvent2016/advent"
+)
+
+// ReadPicture reads a picture drawn with `#` for lit pixels and `.` for dark
+// ones, like the output of Screen.String. A blank line is a row of dark pixels.
+// The picture is drawn in the top left corner of a screen of the given size,
+// and it's an error for it not to fit.
+func ReadPicture(in *advent.Input, width, height int) (*Screen, error) {
+	screen := NewScreen(width, height)
+
+	// Blank lines are rows too, so they mustn't be skipped.
+	in.KeepBlank = true
+
+	y := 0
+	for in.Scan() {
+		line := in.Text()
+
+		// Dark rows below the screen, like blank lines at the end of the
+		// file, don't draw anything, so they still fit.
+		if line == "" {
+			y++
+			continue
+		}
+		if y >= height {
+			return nil, in.Errorf(0, "", "The picture is more than %d pixels tall", height)
+		}
+		if len(line) > width {
+			return nil, in.Errorf(width+1, line[width:], "The picture is more than %d pixels wide", width)
+		}
+
+		for x, pixel := range line {
+			switch pixel {
+			case '#':
+				screen.Light(x, y)
+			case '.':
+			default:
+				return nil, in.Errorf(x+1, string(pixel), "Invalid pixel %q: should be # or .", pixel)
+			}
+		}
+		y++
+	}
+
+	return screen, in.Err()
+}
+
+// Synthesize works out a list of instructions that draws a picture on a blank
+// screen of the same size, using only the puzzle's `rect`, `rotate row` and
+// `rotate column` instructions.
+//
+// The picture is drawn one column at a time from right to left. Each column is
+// drawn in the first column of the screen, where `rect 1xN` lights the top of
+// it and `rotate column x=0` pushes those pixels down into place. Then every
+// row with a pixel in the first column is rotated right, far enough that the
+// pixel lands where the next pixel drawn in that row will end up. By the time
+// the last column is drawn, every pixel has been rotated to its place.
+func Synthesize(picture *Screen) []string {
+	var (
+		width, height = picture.Pixels.Width(), picture.Pixels.Height()
+		script        = []string{}
+	)
+
+	// next finds the next column to the left with a pixel lit in a row, or -1
+	// if there are none.
+	next := func(x, y int) int {
+		for x--; x >= 0; x-- {
+			if lit, _ := picture.IsLit(x, y); lit {
+				return x
+			}
+		}
+		return -1
+	}
+
+	for x := width - 1; x >= 0; x-- {
+		// Find the runs of lit pixels in this column, from the bottom up.
+		type run struct{ start, length int }
+		runs := []run{}
+		for y := height - 1; y >= 0; y-- {
+			if lit, _ := picture.IsLit(x, y); !lit {
+				continue
+			}
+			if len(runs) > 0 && runs[len(runs)-1].start == y+1 {
+				runs[len(runs)-1].start = y
+				runs[len(runs)-1].length++
+			} else {
+				runs = append(runs, run{y, 1})
+			}
+		}
+
+		// Draw the runs at the top of the first column, pushing each one down
+		// just far enough to make room for the next.
+		for i, r := range runs {
+			script = append(script, fmt.Sprintf("rect 1x%d", r.length))
+
+			down := r.start
+			if i+1 < len(runs) {
+				down -= runs[i+1].start
+			}
+			if down > 0 {
+				script = append(script, fmt.Sprintf("rotate column x=0 by %d", down))
+			}
+		}
+
+		// Move the column's pixels out of the way of the next column.
+		for _, r := range runs {
+			for y := r.start; y < r.start+r.length; y++ {
+				right := x
+				if left := next(x, y); left >= 0 {
+					right -= left
+				}
+				if right > 0 {
+					script = append(script, fmt.Sprintf("rotate row y=%d by %d", y, right))
+				}
+			}
+		}
+	}
+
+	return script
+}
+
+// Replay runs instructions on a blank screen of the same size as a picture,
+// and checks that they draw it.
+func Replay(script []string, picture *Screen) error {
+	screen := NewScreen(picture.Pixels.Width(), picture.Pixels.Height())
+	for i, instruction := range script {
+		if err := screen.ProcessInstruction(instruction); err != nil {
+			return fmt.Errorf("Instruction %d (%s): %s", i+1, instruction, err)
+		}
+	}
+
+	if screen.String() != picture.String() {
+		return fmt.Errorf("The instructions drew:\n%s\n\nInstead of:\n%s", screen, picture)
+	}
+	return nil
+}
+
+// WriteScript draws a picture as instructions in the puzzle's input format,
+// after checking that they work.
+func WriteScript(w io.Writer, picture *Screen) error {
+	script := Synthesize(picture)
+	if err := Replay(script, picture); err != nil {
+		return err
+	}
+
+	for _, instruction := range script {
+		if _, err := fmt.Fprintln(w, instruction); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package day09
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/kirsle/goadvent2016/advent"
+)
+
+const (
+	// The default limit on how deep markers can be nested, as a sanity check.
+	RecursionLimit = 100
+)
+
+// For debug output: export ADVENT_LOG=day09=debug (or trace)
+var logger = advent.NewLogger("day09")
+
+// Type Solver decompresses the input, with options from the command line.
+type Solver struct {
+	MaxDepth int // How deep markers can be nested
+}
+
+func init() {
+	advent.Register(9, &Solver{})
+}
+
+// Flags adds the day 9 options to the `advent run` command.
+func (s *Solver) Flags(fs *flag.FlagSet) {
+	fs.IntVar(&s.MaxDepth, "max-depth", RecursionLimit, "Day 9: how deep markers can be nested")
+}
+
+// Solve works out the length of the decompressed input file. The part of the
+// puzzle selects the version of the decompression algorithm.
+func (s *Solver) Solve(part int, in *advent.Input) (advent.Result, error) {
+	lines, err := in.Lines()
+	if err != nil {
+		return advent.Result{}, err
+	}
+	input := strings.Join(lines, "")
+
+	d := NewDecompressor(part)
+	if s.MaxDepth > 0 {
+		d.MaxDepth = s.MaxDepth
+	}
+	size, err := d.Length(input)
+	if err != nil {
+		return advent.Result{}, err
+	}
+	result := advent.Result{Answer: size}
+
+	// When debugging, show the start of the decompressed data.
+	if logger.Enabled(advent.LevelDebug) {
+		r := d.Reader(input)
+		defer r.Close()
+
+		preview, err := ioutil.ReadAll(io.LimitReader(r, 256))
+		if err != nil {
+			return result, err
+		}
+		result.Diagnose("Decoded output: %s", advent.Truncate(string(preview), 255))
+	}
+	return result, nil
+}
+
+// Decompress decompresses the input and returns the data along with its
+// length. The data is all kept in memory, so this is only for small inputs:
+// use a Decompressor to stream big ones.
+func Decompress(input string, version int) (string, int, error) {
+	logger.Debug("### INPUT: %s ###", input)
+
+	var buf bytes.Buffer
+	if _, err := NewDecompressor(version).Expand(&buf, input); err != nil {
+		return "", 0, err
+	}
+
+	logger.Debug("--- OUTPUT(len=%d): %s", buf.Len(), advent.Truncate(buf.String(), 255))
+	return buf.String(), buf.Len(), nil
+}
@@ -0,0 +1,284 @@
+package day09
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/kirsle/goadvent2016/advent"
+)
+
+// Type TestCase contains test cases.
+type TestCase struct {
+	Input          string // The input to the algorithm.
+	ExpectedOutput string // The expected result (don't check if empty)
+	ExpectedLength int    // The expected length of the result
+	ShouldError    bool   // Whether we're expecting an error or not
+}
+
+func TestDecompressV1(t *testing.T) {
+	tests := []TestCase{
+		TestCase{"ADVENT", "ADVENT", 6, false},
+		TestCase{"A(1x5)BC", "ABBBBBC", 7, false},
+		TestCase{"(3x3)XYZ", "XYZXYZXYZ", 9, false},
+		TestCase{"A(2x2)BCD(2x2)EFG", "ABCBCDEFEFG", 11, false},
+		TestCase{"(6x1)(1x3)A", "(1x3)A", 6, false},
+		TestCase{"X(8x2)(3x3)ABCY", "X(3x3)ABC(3x3)ABCY", 18, false},
+		TestCase{"A(5x2)BC", "", 0, true},
+	}
+	runTestCases(t, tests, 1)
+}
+
+func TestDecompressV2(t *testing.T) {
+	tests := []TestCase{
+		// Good test cases.
+		TestCase{"ADVENT", "ADVENT", 6, false},
+		TestCase{"A(1x5)BC", "ABBBBBC", 7, false},
+		TestCase{"(3x3)XYZ", "XYZXYZXYZ", 9, false},
+		TestCase{"X(8x2)(3x3)ABCY", "XABCABCABCABCABCABCY", 20, false},
+		TestCase{"(27x12)(20x12)(13x14)(7x10)(1x12)A", strings.Repeat("A", 241920), 241920, false},
+		TestCase{"(25x3)(3x3)ABC(2x3)XY(5x2)PQRSTX(18x9)(3x2)TWO(5x7)SEVEN", "", 445, false},
+
+		// Bad test cases.
+		TestCase{"(8x2)(9x3)ABCY", "", 0, true},
+	}
+	runTestCases(t, tests, 2)
+}
+
+func runTestCases(t *testing.T, tests []TestCase, version int) {
+	for _, test := range tests {
+		// The length is worked out without decompressing, and should agree.
+		length, lengthErr := NewDecompressor(version).Length(test.Input)
+
+		output, size, err := Decompress(test.Input, version)
+		if (err != nil) != (lengthErr != nil) {
+			t.Errorf("Decompress and Length disagree about errors: %v and %v", err, lengthErr)
+		}
+		if err != nil {
+			if !test.ShouldError {
+				t.Errorf("Unexpected error from test: %v", err)
+			}
+			continue
+		} else if test.ShouldError {
+			t.Errorf("Expected an error from %s", test.Input)
+			continue
+		}
+
+		if length != int64(test.ExpectedLength) {
+			t.Errorf(`Length assertion error: expected "%d", got "%d"`, test.ExpectedLength, length)
+		}
+
+		if len(test.ExpectedOutput) > 0 && output != test.ExpectedOutput {
+			t.Errorf(`Output assertion error: expected "%s", got "%s"`, test.ExpectedOutput, output)
+		}
+		if size != test.ExpectedLength {
+			t.Errorf(`Output length assertion error: expected "%d", got "%d"`, test.ExpectedLength, size)
+		}
+	}
+}
+
+func TestReader(t *testing.T) {
+	input := "X(8x2)(3x3)ABCY(27x12)(20x12)(13x14)(7x10)(1x12)A"
+	expect := "XABCABCABCABCABCABCY" + strings.Repeat("A", 241920)
+
+	// Read it a few bytes at a time.
+	r := NewDecompressor(2).Reader(input)
+	var output strings.Builder
+	buf := make([]byte, 7)
+	for {
+		n, err := r.Read(buf)
+		output.Write(buf[:n])
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("Unexpected error from Read: %s", err)
+		}
+	}
+	if output.String() != expect {
+		t.Errorf("Reader assertion error: expected %d bytes, got %d", len(expect), output.Len())
+	}
+
+	// Stop reading part way through the puzzle input, which is far too big to
+	// read all of.
+	r = NewDecompressor(2).Reader("(27x12)(20x12)(13x14)(7x10)(1x12)A" + strings.Repeat("(50x1000000)", 10) + "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA")
+	start, err := ioutil.ReadAll(io.LimitReader(r, 100))
+	if err != nil || string(start) != strings.Repeat("A", 100) {
+		t.Errorf("Reader assertion error: expected 100 As, got %q (%v)", start, err)
+	}
+	if err := r.Close(); err != nil {
+		t.Errorf("Unexpected error from Close: %s", err)
+	}
+
+	// Errors come out of the reader once it gets to them.
+	_, err = ioutil.ReadAll(NewDecompressor(1).Reader("ABC(5x2)D"))
+	if err == nil {
+		t.Errorf("Reader assertion error: expected an error for a marker past the end")
+	}
+}
+
+func TestTokenizer(t *testing.T) {
+	tests := []struct {
+		input  string
+		expect []string
+	}{
+		{"ADVENT", []string{"ADVENT"}},
+		{"A(1x5)BC", []string{"A", "(1x5)", "BC"}},
+		{"(6x1)(1x3)A", []string{"(6x1)", "(1x3)", "A"}},
+		{"A(x5)B(1x)C(12x34", []string{"A(x5)B(1x)C(12x34"}},
+		{"((1x2)", []string{"(", "(1x2)"}},
+	}
+
+	for _, test := range tests {
+		tokenizer := NewTokenizer(test.input)
+		tokens := []string{}
+		for !tokenizer.Done() {
+			token, err := tokenizer.Next(len(test.input))
+			if err != nil {
+				t.Errorf("%s: unexpected error: %s", test.input, err)
+				break
+			}
+			tokens = append(tokens, token.Text)
+		}
+		if strings.Join(tokens, " ") != strings.Join(test.expect, " ") {
+			t.Errorf("%s: Tokens assertion error: expected %q, got %q", test.input, test.expect, tokens)
+		}
+	}
+
+	// A marker that runs past the end is text.
+	token, _ := NewTokenizer("(1x5)A").Next(4)
+	if token.Kind != TextToken || token.Text != "(1x5" {
+		t.Errorf("Next assertion error: expected text (1x5, got %v", token)
+	}
+
+	if _, err := NewTokenizer("(1x99999999999)A").Next(20); err == nil {
+		t.Errorf("Next assertion error: expected an error for a huge marker")
+	}
+
+	// The biggest number a marker can have, and one past it.
+	if token, err := NewTokenizer("(1x2147483647)A").Next(20); err != nil || token.Repeat != MaxMarkerValue {
+		t.Errorf("Next assertion error: expected a repeat of %d, got %v (%v)", MaxMarkerValue, token, err)
+	}
+	if _, err := NewTokenizer("(1x2147483648)A").Next(20); err == nil {
+		t.Errorf("Next assertion error: expected an error for a marker over the limit")
+	}
+}
+
+func TestLength(t *testing.T) {
+	input, err := ioutil.ReadFile("input.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []string{
+		"A(2x2)BCD(2x2)EFG",
+		"(25x3)(3x3)ABC(2x3)XY(5x2)PQRSTX(18x9)(3x2)TWO(5x7)SEVEN",
+		"(3x2)ABCDE(2x2)FG",
+		"(8x2)A(2x3)BC(1x3)D",
+		"(8x2)(3x4)ABC",
+		strings.TrimSpace(string(input)),
+	}
+	for _, test := range tests {
+		for version := 1; version <= 2; version++ {
+			expect, expectErr := regexpLength(test, version)
+			length, err := NewDecompressor(version).Length(test)
+			if expectErr != nil {
+				t.Errorf("v%d %s: bad test: %s", version, advent.Truncate(test, 20), expectErr)
+			} else if err != nil {
+				t.Errorf("v%d %s: unexpected error: %s", version, advent.Truncate(test, 20), err)
+			} else if length != expect {
+				t.Errorf("v%d %s: Length assertion error: expected %d, got %d", version, advent.Truncate(test, 20), expect, length)
+			}
+		}
+	}
+
+	// The nesting limit.
+	d := NewDecompressor(2)
+	d.MaxDepth = 2
+	if _, err := d.Length("(12x2)(6x2)(1x2)A"); err == nil {
+		t.Errorf("Length assertion error: expected an error for markers nested 3 deep")
+	}
+	if _, err := d.Expand(ioutil.Discard, "(12x2)(6x2)(1x2)A"); err == nil {
+		t.Errorf("Expand assertion error: expected an error for markers nested 3 deep")
+	}
+	if length, err := d.Length("(6x2)(1x2)A(6x2)(1x2)B"); err != nil || length != 8 {
+		t.Errorf("Length assertion error: expected 8, got %d (%v)", length, err)
+	}
+
+	// Nested markers whose repeats multiply past the biggest int.
+	overflow := "(30x2000000000)(15x2000000000)(1x2000000000)A"
+	if length, err := NewDecompressor(2).Length(overflow); err == nil {
+		t.Errorf("Length assertion error: expected an overflow error, got %d", length)
+	}
+
+	// Markers that run past the end of the segment they're in.
+	for _, test := range []string{"(7x2)A(2x3)BC", "(5x2)(3x4)ABC"} {
+		if _, err := NewDecompressor(2).Length(test); err == nil {
+			t.Errorf("Length assertion error: expected an error for %s", test)
+		}
+	}
+}
+
+// markerRegexp finds the next marker, and the text before it.
+var markerRegexp = regexp.MustCompile(`(\w*)\((\d+?)x(\d+?)\)`)
+
+// regexpLength works out the decompressed length the way it used to be done,
+// with a regexp that searches the rest of the input for each marker and
+// recursion into each segment. It's kept to check and benchmark Length
+// against.
+func regexpLength(input string, version int) (int64, error) {
+	var total int64
+	for idx := 0; idx < len(input); {
+		match := markerRegexp.FindStringSubmatch(input[idx:])
+		if len(match) == 0 {
+			total += int64(len(input[idx:]))
+			break
+		}
+
+		ints, _ := advent.StringsToInts(match[2:])
+		length, repeat := ints[0], ints[1]
+		total += int64(len(match[1]))
+
+		idx += len(match[0])
+		if idx+length > len(input) {
+			return 0, fmt.Errorf("Marker %s wants too many characters", match[0])
+		}
+		segment := input[idx : idx+length]
+		idx += length
+
+		size := int64(len(segment))
+		if version == 2 {
+			var err error
+			if size, err = regexpLength(segment, version); err != nil {
+				return 0, err
+			}
+		}
+		total += size * int64(repeat)
+	}
+
+	return total, nil
+}
+
+func BenchmarkLength(b *testing.B) {
+	data, err := ioutil.ReadFile("input.txt")
+	if err != nil {
+		b.Fatal(err)
+	}
+	input := strings.TrimSpace(string(data))
+
+	for version := 1; version <= 2; version++ {
+		b.Run(fmt.Sprintf("v%d/Tokenizer", version), func(b *testing.B) {
+			d := NewDecompressor(version)
+			for n := 0; n < b.N; n++ {
+				d.Length(input)
+			}
+		})
+		b.Run(fmt.Sprintf("v%d/Regexp", version), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				regexpLength(input, version)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package day09
+
+import (
+	"fmt"
+	"io"
+	"math"
+)
+
+// Type Decompressor decompresses data in one of the versions of the format.
+//
+// The decompressed data can be far too big to hold in memory (the puzzle input
+// is 11GB in version 2), so it's streamed out instead: only the compressed
+// input is kept in memory, plus a little for each level of nested markers.
+type Decompressor struct {
+	Version  int
+	MaxDepth int // How deep markers can be nested in version 2
+}
+
+// NewDecompressor creates a decompressor for a version of the format.
+func NewDecompressor(version int) *Decompressor {
+	return &Decompressor{
+		Version:  version,
+		MaxDepth: RecursionLimit,
+	}
+}
+
+// Expand decompresses the input into a writer, and returns how many bytes it
+// wrote. Wrap the writer in a bufio.Writer if it's slow to write to.
+func (d *Decompressor) Expand(w io.Writer, input string) (int64, error) {
+	var written int64
+	err := d.expand(w, input, 1, &written)
+	return written, err
+}
+
+// expand decompresses the input into a writer, counting the bytes written. The
+// depth is how deep the markers in the input are nested.
+func (d *Decompressor) expand(w io.Writer, input string, depth int, written *int64) error {
+	write := func(s string) error {
+		n, err := io.WriteString(w, s)
+		*written += int64(n)
+		return err
+	}
+
+	t := NewTokenizer(input)
+	for !t.Done() {
+		token, err := t.Next(len(input))
+		if err != nil {
+			return err
+		}
+		if token.Kind == TextToken {
+			if err := write(token.Text); err != nil {
+				return err
+			}
+			continue
+		}
+
+		segment, ok := t.Take(token.Length)
+		if !ok {
+			return tooLong(token, len(input)-token.End())
+		}
+		if d.Version == 2 && depth > d.MaxDepth {
+			return tooDeep(token, d.MaxDepth)
+		}
+
+		// Write out the segment, expanding the markers in it each time for
+		// version 2.
+		for i := 0; i < token.Repeat; i++ {
+			if d.Version == 2 {
+				err = d.expand(w, segment, depth+1, written)
+			} else {
+				err = write(segment)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Reader returns a reader of the decompressed input. The data is decompressed
+// as it's read, so reading only the start of it is cheap. Close the reader if
+// it isn't read to the end.
+func (d *Decompressor) Reader(input string) io.ReadCloser {
+	r, w := io.Pipe()
+	go func() {
+		_, err := d.Expand(w, input)
+		w.CloseWithError(err)
+	}()
+	return r
+}
+
+// Length works out how long the decompressed input is, without decompressing
+// it, in one pass through the input.
+//
+// In version 2 each character of text is repeated once for every marker that
+// covers it, times how many times they each repeat. So a stack of the markers
+// that cover the current position is kept, each with the product of its
+// repeats and the repeats of those under it, and the text is counted that
+// many times over.
+//
+// The length is an int64 like Expand's, since in version 2 it can be far more
+// than a 32-bit int can hold.
+func (d *Decompressor) Length(input string) (int64, error) {
+	// Type span is the part of the input that a marker covers.
+	type span struct {
+		end    int   // Where its segment ends
+		weight int64 // How many times each character in it is repeated
+	}
+
+	var (
+		total int64
+		t     = NewTokenizer(input)
+		stack = []span{{end: len(input), weight: 1}}
+	)
+	for !t.Done() {
+		// Leave the segments that have ended.
+		for stack[len(stack)-1].end <= t.Pos() {
+			stack = stack[:len(stack)-1]
+		}
+		top := stack[len(stack)-1]
+
+		// Tokens stop at the end of the segment they're in.
+		token, err := t.Next(top.end)
+		if err != nil {
+			return 0, err
+		}
+		if token.Kind == TextToken {
+			if total, err = addLength(total, int64(len(token.Text)), top.weight); err != nil {
+				return 0, err
+			}
+			continue
+		}
+
+		start, end := token.End(), token.End()+token.Length
+		if end > top.end {
+			return 0, tooLong(token, top.end-start)
+		}
+
+		if d.Version != 2 {
+			// Version 1 repeats the segment as it is.
+			t.Take(token.Length)
+			if total, err = addLength(total, int64(token.Length), int64(token.Repeat), top.weight); err != nil {
+				return 0, err
+			}
+			continue
+		}
+
+		if len(stack) > d.MaxDepth {
+			return 0, tooDeep(token, d.MaxDepth)
+		}
+		repeat := int64(token.Repeat)
+		if repeat > 0 && top.weight > math.MaxInt64/repeat {
+			return 0, fmt.Errorf("Marker %s at %d repeats its segment too many times: the length overflows", token.Text, token.Pos)
+		}
+		stack = append(stack, span{end, top.weight * repeat})
+	}
+
+	return total, nil
+}
+
+// addLength adds the product of some factors, like a number of characters and
+// how many times they're repeated, to a total length. It returns an error if
+// the multiplying or the adding overflows.
+func addLength(total int64, factors ...int64) (int64, error) {
+	n := int64(1)
+	for _, factor := range factors {
+		if factor != 0 && n > math.MaxInt64/factor {
+			return 0, tooBig()
+		}
+		n *= factor
+	}
+	if n > math.MaxInt64-total {
+		return 0, tooBig()
+	}
+	return total + n, nil
+}
+
+// tooBig is the error for a decompressed length that doesn't fit in an int64.
+func tooBig() error {
+	return fmt.Errorf("The decompressed length is more than %d", int64(math.MaxInt64))
+}
+
+// tooLong is the error for a marker that wants more characters than there are
+// left in the input, or in the segment that it's in.
+func tooLong(marker Token, left int) error {
+	return fmt.Errorf("Marker %s wants %d characters but only %d are left", marker.Text, marker.Length, left)
+}
+
+// tooDeep is the error for a marker that's nested too deep.
+func tooDeep(marker Token, limit int) error {
+	return fmt.Errorf("Marker %s at %d is nested more than %d deep", marker.Text, marker.Pos, limit)
+}
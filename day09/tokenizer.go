@@ -0,0 +1,157 @@
+package day09
+
+import (
+	"fmt"
+	"math"
+)
+
+// Type TokenKind is the kind of a Token: plain text or a marker.
+type TokenKind int
+
+const (
+	TextToken TokenKind = iota
+	MarkerToken
+)
+
+// Type Token is a piece of the compressed data.
+type Token struct {
+	Kind   TokenKind
+	Pos    int    // Where the token starts in the input
+	Text   string // The token as it's written in the input
+	Length int    // For a marker, how many characters it repeats
+	Repeat int    // For a marker, how many times it repeats them
+}
+
+// End returns where the token ends in the input.
+func (t Token) End() int {
+	return t.Pos + len(t.Text)
+}
+
+// MaxMarkerValue is the biggest number a marker can have in it, so that the
+// numbers fit in an int on every platform. Multiplying them together can still
+// overflow, so Length checks for that.
+const MaxMarkerValue = math.MaxInt32
+
+// Type Tokenizer splits compressed data into text and markers, like
+// `A(1x5)BC` into `A`, `(1x5)` and `BC`. It reads through the input once,
+// and never looks back.
+type Tokenizer struct {
+	input string
+	pos   int
+}
+
+// NewTokenizer creates a tokenizer for some compressed data.
+func NewTokenizer(input string) *Tokenizer {
+	return &Tokenizer{
+		input: input,
+	}
+}
+
+// Pos returns where the tokenizer is up to in the input.
+func (t *Tokenizer) Pos() int {
+	return t.pos
+}
+
+// Done tells whether the whole input has been read.
+func (t *Tokenizer) Done() bool {
+	return t.pos >= len(t.input)
+}
+
+// Next reads the next token, which has to finish before the end position: a
+// marker that would run past it is read as text instead. The text stops at
+// the next marker, or at the end.
+func (t *Tokenizer) Next(end int) (Token, error) {
+	if end > len(t.input) {
+		end = len(t.input)
+	}
+	start := t.pos
+
+	if marker, ok, err := t.marker(start, end); err != nil || ok {
+		if ok {
+			t.pos = marker.End()
+		}
+		return marker, err
+	}
+
+	// It's text, up to the next bracket that starts a marker. A bracket that
+	// doesn't start one is just text.
+	i := start + 1
+	for ; i < end; i++ {
+		if t.input[i] != '(' {
+			continue
+		}
+		if _, ok, err := t.marker(i, end); err != nil || ok {
+			break
+		}
+	}
+
+	t.pos = i
+	return Token{
+		Kind: TextToken,
+		Pos:  start,
+		Text: t.input[start:i],
+	}, nil
+}
+
+// Take reads the next n characters of input as they are, markers and all. It
+// returns false if there aren't that many left.
+func (t *Tokenizer) Take(n int) (string, bool) {
+	if n > len(t.input)-t.pos {
+		return "", false
+	}
+	s := t.input[t.pos : t.pos+n]
+	t.pos += n
+	return s, true
+}
+
+// marker reads a marker like `(1x5)` that starts at a position and finishes
+// before the end position. It returns false if there isn't one there.
+func (t *Tokenizer) marker(start, end int) (Token, bool, error) {
+	i := start
+	if i >= end || t.input[i] != '(' {
+		return Token{}, false, nil
+	}
+	i++
+
+	// number reads the digits at i, followed by a separator. A number bigger
+	// than MaxMarkerValue stops growing there, and is flagged as too big.
+	tooBig := false
+	number := func(separator byte) (int, bool) {
+		value, digits := 0, 0
+		for ; i < end && '0' <= t.input[i] && t.input[i] <= '9'; i++ {
+			digit := int(t.input[i] - '0')
+			if value > (MaxMarkerValue-digit)/10 {
+				tooBig = true
+			} else {
+				value = value*10 + digit
+			}
+			digits++
+		}
+		if digits == 0 || i >= end || t.input[i] != separator {
+			return 0, false
+		}
+		i++
+		return value, true
+	}
+
+	length, ok := number('x')
+	if !ok {
+		return Token{}, false, nil
+	}
+	repeat, ok := number(')')
+	if !ok {
+		return Token{}, false, nil
+	}
+
+	token := Token{
+		Kind:   MarkerToken,
+		Pos:    start,
+		Text:   t.input[start:i],
+		Length: length,
+		Repeat: repeat,
+	}
+	if tooBig {
+		return token, false, fmt.Errorf("Marker %s at %d has a number bigger than %d", token.Text, start, MaxMarkerValue)
+	}
+	return token, true, nil
+}
@@ -0,0 +1,299 @@
+package day10
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Type ConcurrentEngine runs the factory the way it would run in real life:
+// every bot is a goroutine with a channel for the chips handed to it, and
+// every output is a goroutine that collects the chips that fall into it.
+//
+// Bots give their chips away with Bot.GiveAway like in the other engines. The
+// recipients they give to are stand-ins for the goroutines, which keep count
+// of the chips on their way so that a bot can tell whether there's room.
+//
+// Nobody is in charge, so to know when the factory is finished the engine
+// counts the chips that are on their way somewhere. A chip counts from when
+// it's sent until its recipient has dealt with it, including handing chips
+// on. Once no chips are moving the factory has stalled for good, and the
+// goroutines are shut down. If any bot is still holding chips then, it's
+// deadlocked waiting for chips that will never come, and that's an error.
+type ConcurrentEngine struct {
+	Delay time.Duration // Pause before each bot gives away its chips
+}
+
+// Type delivery is a chip moving along a channel.
+type delivery struct {
+	chip Microchip
+	from string // Who sent it, for the logs
+}
+
+// Type network is the shared state of the goroutines.
+type network struct {
+	ctx      context.Context
+	delay    time.Duration
+	bots     map[string]*remote
+	outputs  map[string]*remote
+	moving   int64         // Chips on their way somewhere
+	stalled  chan struct{} // Closed when no chips are moving
+	errs     chan error
+	finished sync.WaitGroup
+
+	// Chips are only given while holding the lock, so that checking for room
+	// and sending the chips happen as one.
+	lock   sync.Mutex
+	sender string // Who's giving chips away right now, for the logs
+}
+
+// Type remote is a Recipient for a bot or output that's run by a goroutine.
+// It keeps count of the chips the goroutine holds or has on their way to it;
+// the count is only touched while holding the network's lock.
+type remote struct {
+	n     *network
+	name  string
+	toBot bool
+	held  int
+	in    chan delivery
+}
+
+// String names the recipient, like "Bot 1" or "Output 2".
+func (r *remote) String() string {
+	return r.name
+}
+
+// Accepts tells whether the recipient will have room for the chips once the
+// chips on their way to it arrive. Outputs always have room.
+func (r *remote) Accepts(chips ...Microchip) bool {
+	return r.toBot != ToBot || r.held+len(chips) <= 2
+}
+
+// Accept sends a chip to the recipient's goroutine.
+func (r *remote) Accept(chip Microchip) {
+	r.held++
+	atomic.AddInt64(&r.n.moving, 1)
+	r.in <- delivery{chip, r.n.sender}
+}
+
+// give hands chips to recipients while holding the lock, so that nothing else
+// can fill them up in between. It returns false if they don't have room.
+func (n *network) give(sender string, fn func() bool) bool {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.sender = sender
+	return fn()
+}
+
+// Run runs the instructions.
+func (e *ConcurrentEngine) Run(ctx context.Context, steps *Steps, bots *Bots, outputs *Outputs) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Set up every bot and output before the goroutines start, since they
+	// can't safely add to the maps while they run. The channels are big
+	// enough to hold every chip so a send never blocks.
+	rules := map[string]*Step{}
+	inputs := []*Step{}
+	for _, step := range steps.steps {
+		bots.Find(step.BotID)
+		if step.Action == InputAction {
+			inputs = append(inputs, step)
+			continue
+		}
+
+		if _, ok := rules[step.BotID]; ok {
+			return fmt.Errorf("Bot %s has more than one give rule", step.BotID)
+		}
+		rules[step.BotID] = step
+		for _, to := range step.Targets() {
+			if to.ToBot == ToBot {
+				bots.Find(to.ID)
+			} else {
+				outputs.Find(to.ID)
+			}
+		}
+	}
+
+	n := &network{
+		ctx:     ctx,
+		delay:   e.Delay,
+		bots:    map[string]*remote{},
+		outputs: map[string]*remote{},
+		stalled: make(chan struct{}),
+		errs:    make(chan error, 1),
+	}
+	for id, bot := range bots.bots {
+		n.bots[id] = &remote{n, bot.String(), ToBot, 0, make(chan delivery, len(inputs))}
+	}
+	for id, output := range outputs.outputs {
+		n.outputs[id] = &remote{n, output.String(), ToOutput, 0, make(chan delivery, len(inputs))}
+	}
+
+	// Start the goroutines.
+	for id, bot := range bots.bots {
+		n.finished.Add(1)
+		go n.runBot(bot, rules[id], n.bots[id])
+	}
+	for id, output := range outputs.outputs {
+		n.finished.Add(1)
+		go n.runOutput(output, n.outputs[id].in)
+	}
+
+	// Drop in the chips from the inputs. The inputs count as a chip on the
+	// move until they've all been sent, so the count can't reach zero early.
+	atomic.AddInt64(&n.moving, 1)
+	for _, input := range inputs {
+		to := n.bots[input.BotID]
+		ok := n.give("Input", func() bool {
+			if !to.Accepts(input.Value) {
+				return false
+			}
+			to.Accept(input.Value)
+			return true
+		})
+		if !ok {
+			n.fail(fmt.Errorf("Bot %s can't take chip %d: its hands are full", input.BotID, input.Value))
+			break
+		}
+		input.Done = true
+	}
+	n.done()
+
+	// Wait for the factory to stall, then shut it down. An error wins over a
+	// stall that happened at the same time.
+	var err error
+	select {
+	case <-n.stalled:
+		logger.Debug("The factory has stalled: no chips are moving")
+	case err = <-n.errs:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	if err == nil {
+		select {
+		case err = <-n.errs:
+		default:
+		}
+	}
+
+	cancel()
+	n.finished.Wait()
+	if err == nil {
+		err = deadlocked(bots)
+	}
+
+	// Mark the rules that were carried out, now that it's safe to look at the
+	// bots again.
+	for id, rule := range rules {
+		rule.Done = len(bots.bots[id].History) > 0
+	}
+	return err
+}
+
+// deadlocked returns an error naming the bots that are still holding chips
+// after the factory has stalled, if there are any.
+func deadlocked(bots *Bots) error {
+	stuck := []string{}
+	for _, bot := range bots.List() {
+		if len(bot.Inventory) > 0 {
+			stuck = append(stuck, fmt.Sprintf("%s holds %v", bot, bot.Inventory))
+		}
+	}
+	if len(stuck) == 0 {
+		return nil
+	}
+	return fmt.Errorf("The factory is deadlocked: %s", strings.Join(stuck, ", "))
+}
+
+// done marks a chip as dealt with. The last one to be dealt with means the
+// factory has stalled.
+func (n *network) done() {
+	if atomic.AddInt64(&n.moving, -1) == 0 {
+		close(n.stalled)
+	}
+}
+
+// fail reports an error, if one wasn't reported already.
+func (n *network) fail(err error) {
+	select {
+	case n.errs <- err:
+	default:
+	}
+}
+
+// recipients finds the recipients of a give rule.
+func (n *network) recipients(rule *Step) (low, high Recipient) {
+	find := func(toBot bool, id string) Recipient {
+		if toBot == ToBot {
+			return n.bots[id]
+		}
+		return n.outputs[id]
+	}
+	return find(rule.LowTo, rule.LowID), find(rule.HighTo, rule.HighID)
+}
+
+// runBot is the goroutine for a bot. It collects chips until its hands are
+// full and then follows its give rule.
+func (n *network) runBot(bot *Bot, rule *Step, self *remote) {
+	defer n.finished.Done()
+
+	for {
+		var d delivery
+		select {
+		case <-n.ctx.Done():
+			return
+		case d = <-self.in:
+		}
+
+		if !bot.Give(d.chip) {
+			n.fail(fmt.Errorf("Bot %s can't take chip %d from %s: its hands are full", bot.ID, d.chip, d.from))
+			return
+		}
+		botLog.Trace("[ OK ] %s gave %d to Bot %s", d.from, d.chip, bot.ID)
+
+		if bot.Full() && rule != nil {
+			if err := wait(n.ctx, n.delay); err != nil {
+				return
+			}
+
+			// The inventory is already sorted.
+			lower, higher := bot.Inventory[0], bot.Inventory[1]
+			low, high := n.recipients(rule)
+			ok := n.give(bot.String(), func() bool {
+				if !bot.GiveAway(low, high) {
+					return false
+				}
+				self.held -= 2
+				return true
+			})
+			if !ok {
+				n.fail(fmt.Errorf("Bot %s can't give L%d to %s and H%d to %s: no room", bot.ID, lower, low, higher, high))
+				return
+			}
+			botLog.Trace("[ OK ] Bot %s gave L%d to %s and H%d to %s", bot.ID, lower, low, higher, high)
+		}
+
+		n.done()
+	}
+}
+
+// runOutput is the goroutine for an output bin, which collects every chip
+// sent to it.
+func (n *network) runOutput(output *Output, in chan delivery) {
+	defer n.finished.Done()
+
+	for {
+		select {
+		case <-n.ctx.Done():
+			return
+		case d := <-in:
+			output.Accept(d.chip)
+			botLog.Trace("[ OK ] %s gave %d to Output %s", d.from, d.chip, output.ID)
+			n.done()
+		}
+	}
+}
@@ -0,0 +1,492 @@
+package day10
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kirsle/goadvent2016/advent"
+)
+
+// For debug output: export ADVENT_LOG=day10=debug,day10.bot=trace
+var (
+	logger = advent.NewLogger("day10")
+	botLog = logger.Named("bot")
+)
+
+// Type Solver runs the bot factory, with options from the command line.
+type Solver struct {
+	Engine    string        // Name of the simulation engine
+	TickDelay time.Duration // Pause between moves, for watching it play out
+
+	// Questions to ask once the factory is finished.
+	FindCompare    string // Part 1: which bot compared these two chips
+	ProductOutputs string // Part 2: multiply the chips in these outputs
+	HeldChips      string // Which bots held these chips
+}
+
+func init() {
+	advent.Register(10, &Solver{})
+}
+
+// Flags adds the day 10 options to the `advent run` command.
+func (s *Solver) Flags(fs *flag.FlagSet) {
+	fs.StringVar(&s.Engine, "engine", "event", "Day 10: simulation engine: "+strings.Join(EngineNames(), ", "))
+	fs.DurationVar(&s.TickDelay, "tick-delay", 0, "Day 10: pause between moves to watch the bots at work, like 500ms")
+	fs.StringVar(&s.FindCompare, "find-compare", "17,61", "Day 10: part 1 finds the bot that compared these two chips")
+	fs.StringVar(&s.ProductOutputs, "product-outputs", "0,1,2", "Day 10: part 2 multiplies the chips in these outputs")
+	fs.StringVar(&s.HeldChips, "held-chip", "", "Day 10: list the bots that ever held these chips, like 17,61")
+}
+
+// Solve runs the bot factory. Part 1 finds the bot that compares the chips 17
+// and 61, and part 2 multiplies the chips that end up in outputs 0, 1 and 2,
+// unless the Solver asks about other chips and outputs.
+func (s *Solver) Solve(part int, in *advent.Input) (advent.Result, error) {
+	query, err := s.Query()
+	if err != nil {
+		return advent.Result{}, err
+	}
+
+	factory, err := s.Run(in)
+	if err != nil {
+		return advent.Result{}, err
+	}
+
+	// Pretty print things when debugging.
+	if logger.Enabled(advent.LevelDebug) {
+		logger.Debug("Summary:\n%s", Summary(factory.Bots, factory.Outputs))
+	}
+
+	result, err := query.Answer(part, factory.Bots, factory.Outputs)
+	for _, problem := range factory.Problems {
+		result.Diagnose("Warning: %s", problem)
+	}
+	return result, err
+}
+
+// Type Factory is the bots and outputs after running the instructions.
+type Factory struct {
+	Steps    *Steps
+	Bots     *Bots
+	Outputs  *Outputs
+	Problems []*advent.ParseError // What Validate found wrong with the steps
+}
+
+// Run parses the instructions, checks them for problems and runs the factory
+// with the Solver's engine. A bot with more than one give rule is always an
+// error, and in strict mode so is any other problem; otherwise the problems are
+// kept in the Factory and the factory runs anyway.
+func (s *Solver) Run(in *advent.Input) (*Factory, error) {
+	name := s.Engine
+	if name == "" {
+		name = "event"
+	}
+	newEngine, ok := Engines[name]
+	if !ok {
+		return nil, fmt.Errorf("Unknown engine: %s", name)
+	}
+
+	// Parse the instruction set so we know who all our bots and inputs are.
+	steps, err := ParseInstructions(in)
+	if err != nil {
+		return nil, err
+	}
+	factory := &Factory{
+		Steps:    steps,
+		Bots:     NewBots(),
+		Outputs:  NewOutputs(),
+		Problems: Validate(steps),
+	}
+
+	for _, problem := range factory.Problems {
+		problem.File = in.Name
+		if in.Strict || IsDuplicateRule(problem) {
+			return nil, problem
+		}
+	}
+
+	// Run the steps until we can do no more of them.
+	err = newEngine(s.TickDelay).Run(in.Context(), steps, factory.Bots, factory.Outputs)
+	if err != nil {
+		return nil, err
+	}
+
+	return factory, nil
+}
+
+// Query builds the questions to ask about the factory. The zero value of the
+// Solver asks the puzzle's questions.
+func (s *Solver) Query() (*Query, error) {
+	compare, outputs := s.FindCompare, s.ProductOutputs
+	if compare == "" {
+		compare = "17,61"
+	}
+	if outputs == "" {
+		outputs = "0,1,2"
+	}
+	return ParseQuery(compare, outputs, s.HeldChips)
+}
+
+// Summary pretty prints the final state of the bots and outputs.
+func Summary(bots *Bots, outputs *Outputs) string {
+	var buf bytes.Buffer
+
+	fmt.Fprintln(&buf, "Summary of the Bots")
+	fmt.Fprintln(&buf, "===================")
+	for _, bot := range bots.List() {
+		fmt.Fprintf(&buf, "## Bot %s\n", bot.ID)
+		fmt.Fprintf(&buf, "   Inventory: %v\n", bot.Inventory)
+		fmt.Fprintf(&buf, "   Comparison History:\n")
+		for _, h := range bot.History {
+			fmt.Fprintf(&buf, "      %d <> %d\n", h.A, h.B)
+		}
+	}
+
+	fmt.Fprintln(&buf, "\nSummary of the Outputs")
+	fmt.Fprintln(&buf, "======================")
+	for _, out := range outputs.List() {
+		fmt.Fprintf(&buf, "Output: %s\n", out.ID)
+		fmt.Fprintf(&buf, "Inventory: %v\n", out.Inventory)
+	}
+
+	return buf.String()
+}
+
+// DoOneLoop processes a loop of A.I. instructions until it runs out of things
+// to do.
+func DoOneLoop(steps *Steps, bots *Bots, outputs *Outputs) bool {
+	// Number of steps executed.
+	var executed int
+
+	// Loop through the steps and see if any can be done yet.
+	for _, step := range steps.steps {
+		// Skip spent steps.
+		if step.Done {
+			continue
+		}
+
+		// What sort of step?
+		if step.Action == InputAction {
+			// The input gives a token to a bot.
+			bot := bots.Find(step.BotID)
+			if bot.Give(step.Value) {
+				botLog.Trace("[ OK ] Input gave %d to Bot %s\n", step.Value, step.BotID)
+				step.Done = true
+				executed++
+			} else {
+				botLog.Trace("[FAIL] Input can't give %d to Bot %s: no room\n", step.Value, step.BotID)
+			}
+		} else if step.Action == GiveAction {
+			// Give away a token if we have 2.
+			bot := bots.Find(step.BotID)
+			if !bot.Full() {
+				// Bots only give away chips when their hands are full.
+				continue
+			}
+
+			// Find out the lower and higher value chips. The inventory is
+			// already sorted.
+			lower, higher := bot.Inventory[0], bot.Inventory[1]
+
+			// Both chips go at once, or neither does.
+			low, high := step.Recipients(bots, outputs)
+			if bot.GiveAway(low, high) {
+				botLog.Trace("[ OK ] Bot %s gave L%d to %s and H%d to %s\n", bot.ID, lower, low, higher, high)
+				executed++
+			} else {
+				botLog.Trace("[FAIL] Bot %s can't give L%d to %s and H%d to %s: no room\n", bot.ID, lower, low, higher, high)
+			}
+		}
+	}
+
+	logger.Debug("Executed %d steps", executed)
+	return executed == 0
+}
+
+// ParseInstructions turns the input lines into Step objects.
+func ParseInstructions(in *advent.Input) (*Steps, error) {
+	result := &Steps{
+		steps: []*Step{},
+	}
+
+	for in.Scan() {
+		line := in.Text()
+
+		// Test the regexps.
+		var match []string
+
+		// The Input giving a microchip to a bot.
+		match = RE_Input.FindStringSubmatch(line)
+		if len(match) > 0 {
+			value, err := strconv.Atoi(match[1])
+			if err != nil {
+				return nil, in.Errorf(len("value ")+1, match[1], "Invalid microchip value")
+			}
+			result.steps = append(result.steps, &Step{
+				Action: InputAction,
+				Line:   in.Line(),
+				BotID:  match[2],
+				Value:  Microchip(value),
+			})
+			continue
+		}
+
+		// The give conditions.
+		match = RE_Give.FindStringSubmatch(line)
+		if len(match) > 0 {
+			// Parse the integers out.
+			result.steps = append(result.steps, &Step{
+				Action: GiveAction,
+				Line:   in.Line(),
+				BotID:  match[1],
+				LowTo:  WhoTo(match[2]),
+				LowID:  match[3],
+				HighTo: WhoTo(match[4]),
+				HighID: match[5],
+			})
+			continue
+		}
+
+		// Anything else isn't an instruction we know about.
+		err := in.Errorf(0, line, "Unknown instruction")
+		if err = in.Skip(err); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, in.Err()
+}
+
+// WhoTo identifies who the recipient of a microchip is.
+func WhoTo(name string) bool {
+	return name == "bot"
+}
+
+// Regular expression to match the A.I. steps from the input file.
+var (
+	RE_Input = regexp.MustCompile(`^value (\d+) goes to bot (\d+)$`)
+	RE_Give  = regexp.MustCompile(`^bot (\d+) gives low to (bot|output) (\d+) and high to (bot|output) (\d+)$`)
+)
+
+type ID int
+
+type Steps struct {
+	steps []*Step
+}
+
+// Type Step represents a step of A.I. from the input file to try.
+type Step struct {
+	Action int  // What sort of action this step is
+	Line   int  // Line number in the input file
+	Done   bool // When the step was able to be finished
+
+	// Parsed regexp values.
+	BotID  string    // The actor
+	Value  Microchip // Value to be given to the actor
+	LowTo  bool      // What sort of thing the low is given to (bot or output)
+	LowID  string    // Recipient of the value given by the actor
+	HighTo bool
+	HighID string
+}
+
+// Recipients.
+const (
+	// Valid types for a step.
+	GiveAction = iota
+	InputAction
+
+	// Values for the LowTo and HighTo values of a Step.
+	ToOutput = false
+	ToBot    = true
+)
+
+// Type Bots is a collection of bots.
+type Bots struct {
+	bots map[string]*Bot
+}
+
+func NewBots() *Bots {
+	return &Bots{
+		bots: map[string]*Bot{},
+	}
+}
+
+type Outputs struct {
+	outputs map[string]*Output
+}
+
+func NewOutputs() *Outputs {
+	return &Outputs{
+		outputs: map[string]*Output{},
+	}
+}
+
+// Find finds a bot or creates it if it doesn't exist.
+func (s *Bots) Find(id string) *Bot {
+	if bot, ok := s.bots[id]; ok {
+		return bot
+	}
+	s.bots[id] = &Bot{
+		ID:        id,
+		Inventory: []Microchip{},
+		History:   []History{},
+	}
+	return s.bots[id]
+}
+
+// Find finds an output or creates it if it doesn't exist.
+func (s *Outputs) Find(id string) *Output {
+	if output, ok := s.outputs[id]; ok {
+		return output
+	}
+	s.outputs[id] = &Output{
+		ID:        id,
+		Inventory: []Microchip{},
+	}
+	return s.outputs[id]
+}
+
+// Type Bot represents a robot.
+type Bot struct {
+	ID        string
+	Inventory []Microchip // What the bot is carrying in its hands (max 2)
+	History   []History   // Things this bot has had to do.
+}
+
+// Full checks if a bot's inventory is full.
+func (b *Bot) Full() bool {
+	return len(b.Inventory) >= 2
+}
+
+// Give sees if a bots inventory has room to take a microchip and gives it to
+// the bot if so. Also ensures that the chips are sorted by lower to higher.
+func (b *Bot) Give(chip Microchip) bool {
+	if len(b.Inventory) < 2 {
+		b.Inventory = append(b.Inventory, chip)
+
+		// Keep them sorted.
+		if len(b.Inventory) >= 2 && b.Inventory[0] > b.Inventory[1] {
+			b.Inventory[0], b.Inventory[1] = b.Inventory[1], b.Inventory[0]
+		}
+
+		return true
+	}
+	return false
+}
+
+// Type Recipient is something a bot can hand a chip to: another bot or an
+// output bin.
+type Recipient interface {
+	// String names the recipient, like "Bot 1" or "Output 2".
+	String() string
+
+	// Accepts tells whether the recipient has room for all of the chips.
+	Accepts(chips ...Microchip) bool
+
+	// Accept takes a chip. Check Accepts first.
+	Accept(chip Microchip)
+}
+
+// Type Target is who a give rule hands a chip to.
+type Target struct {
+	ToBot bool // ToBot or ToOutput
+	ID    string
+}
+
+// Targets returns who a give rule hands its low and high chips to.
+func (s *Step) Targets() []Target {
+	return []Target{
+		{s.LowTo, s.LowID},
+		{s.HighTo, s.HighID},
+	}
+}
+
+// Recipients finds who a give rule hands its low and high chips to.
+func (s *Step) Recipients(bots *Bots, outputs *Outputs) (low, high Recipient) {
+	find := func(toBot bool, id string) Recipient {
+		if toBot == ToBot {
+			return bots.Find(id)
+		}
+		return outputs.Find(id)
+	}
+	return find(s.LowTo, s.LowID), find(s.HighTo, s.HighID)
+}
+
+// GiveAway hands the bot's lower chip to one recipient and the higher chip to
+// another, and records the comparison in the bot's history. Either both chips
+// are handed over or, if the bot's hands aren't full or a recipient has no
+// room, neither is.
+func (b *Bot) GiveAway(low, high Recipient) bool {
+	if !b.Full() {
+		return false
+	}
+	lower, higher := b.Inventory[0], b.Inventory[1]
+
+	// Check that both chips can be delivered before moving either of them.
+	if low == high {
+		if !low.Accepts(lower, higher) {
+			return false
+		}
+	} else if !low.Accepts(lower) || !high.Accepts(higher) {
+		return false
+	}
+
+	b.History = append(b.History, History{
+		A: lower,
+		B: higher,
+	})
+	b.Inventory = []Microchip{}
+	low.Accept(lower)
+	high.Accept(higher)
+	return true
+}
+
+// String names the bot.
+func (b *Bot) String() string {
+	return "Bot " + b.ID
+}
+
+// Accepts tells whether the bot has room in its hands for the chips.
+func (b *Bot) Accepts(chips ...Microchip) bool {
+	return len(b.Inventory)+len(chips) <= 2
+}
+
+// Accept puts a chip in the bot's hands.
+func (b *Bot) Accept(chip Microchip) {
+	b.Give(chip)
+}
+
+// Type History is the things a bot has had to do.
+type History struct {
+	A Microchip // Two values the bot has had to compare.
+	B Microchip
+}
+
+// Type Output is an output bin a bot can give chips to.
+type Output struct {
+	ID        string
+	Inventory []Microchip
+}
+
+// String names the output.
+func (o *Output) String() string {
+	return "Output " + o.ID
+}
+
+// Accepts is always true: an output bin has room for any number of chips.
+func (o *Output) Accepts(chips ...Microchip) bool {
+	return true
+}
+
+// Accept drops a chip into the output bin.
+func (o *Output) Accept(chip Microchip) {
+	o.Inventory = append(o.Inventory, chip)
+}
+
+// Type Microchip is a chip that a bot is carrying. Each microchip contains
+// a single number.
+type Microchip int
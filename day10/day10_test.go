@@ -0,0 +1,372 @@
+package day10
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kirsle/goadvent2016/advent"
+)
+
+// The example from the puzzle.
+const example = `value 5 goes to bot 2
+bot 2 gives low to bot 1 and high to bot 0
+value 3 goes to bot 1
+bot 1 gives low to output 1 and high to bot 0
+bot 0 gives low to output 2 and high to output 0
+value 2 goes to bot 2`
+
+// runExample runs the example through an engine.
+func runExample(t *testing.T, engine Engine) (*Bots, *Outputs) {
+	return runInstructions(t, engine, example)
+}
+
+// runInstructions runs some instructions through an engine.
+func runInstructions(t *testing.T, engine Engine, instructions string) (*Bots, *Outputs) {
+	in := advent.NewInput(context.Background(), "example", strings.NewReader(instructions))
+	steps, err := ParseInstructions(in)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	bots, outputs := NewBots(), NewOutputs()
+	if err := engine.Run(context.Background(), steps, bots, outputs); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	return bots, outputs
+}
+
+func TestEngines(t *testing.T) {
+	tests := []struct {
+		instructions string
+		outputs      map[string][]Microchip
+		bot          string // A bot and its expected history
+		history      []History
+	}{
+		{
+			instructions: example,
+			outputs: map[string][]Microchip{
+				"0": {5},
+				"1": {2},
+				"2": {3},
+			},
+			bot:     "2",
+			history: []History{{2, 5}},
+		},
+
+		// A bot that's given both chips by the same rule.
+		{
+			instructions: `value 1 goes to bot 0
+value 2 goes to bot 0
+bot 0 gives low to bot 1 and high to bot 1
+bot 1 gives low to output 0 and high to output 1`,
+			outputs: map[string][]Microchip{
+				"0": {1},
+				"1": {2},
+			},
+			bot:     "1",
+			history: []History{{1, 2}},
+		},
+	}
+
+	for i, test := range tests {
+		for _, name := range EngineNames() {
+			bots, outputs := runInstructions(t, Engines[name](0), test.instructions)
+
+			for id, chips := range test.outputs {
+				if result := outputs.Find(id).Inventory; !reflect.DeepEqual(result, chips) {
+					t.Errorf("Test %d: %s engine: output %s assertion error: expected %v, got %v", i, name, id, chips, result)
+				}
+			}
+
+			history := bots.Find(test.bot).History
+			if !reflect.DeepEqual(history, test.history) {
+				t.Errorf("Test %d: %s engine: bot %s history assertion error: got %v", i, name, test.bot, history)
+			}
+		}
+	}
+}
+
+// The concurrent engine can't hold a chip back until there's room for it the
+// way the tick engine does, so it has to report a bot being overfilled.
+func TestConcurrentFullHands(t *testing.T) {
+	const overfull = `value 1 goes to bot 1
+value 2 goes to bot 1
+value 3 goes to bot 1`
+
+	in := advent.NewInput(context.Background(), "overfull", strings.NewReader(overfull))
+	steps, err := ParseInstructions(in)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	bots, outputs := NewBots(), NewOutputs()
+	engine := &ConcurrentEngine{}
+	if err := engine.Run(context.Background(), steps, bots, outputs); err == nil {
+		t.Errorf("Expected an error for a third chip to bot 1")
+	}
+}
+
+// The concurrent engine reports the bots left holding chips when the factory
+// stalls.
+func TestConcurrentDeadlock(t *testing.T) {
+	const stuck = `value 1 goes to bot 0
+value 2 goes to bot 1
+bot 0 gives low to output 0 and high to output 1`
+
+	in := advent.NewInput(context.Background(), "stuck", strings.NewReader(stuck))
+	steps, err := ParseInstructions(in)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	engine := &ConcurrentEngine{}
+	err = engine.Run(context.Background(), steps, NewBots(), NewOutputs())
+	expected := "The factory is deadlocked: Bot 0 holds [1], Bot 1 holds [2]"
+	if err == nil || err.Error() != expected {
+		t.Errorf("Expected error %q, got %v", expected, err)
+	}
+}
+
+func TestGiveAway(t *testing.T) {
+	tests := []struct {
+		rule       string
+		bots       map[string][]Microchip // What the recipient bots hold after
+		outputs    map[string][]Microchip // What the outputs hold after
+		shouldFail bool
+	}{
+		{
+			rule:    "bot 0 gives low to bot 1 and high to bot 2",
+			bots:    map[string][]Microchip{"1": {2}, "2": {5}},
+			outputs: map[string][]Microchip{},
+		},
+		{
+			rule:    "bot 0 gives low to bot 1 and high to output 2",
+			bots:    map[string][]Microchip{"1": {2}},
+			outputs: map[string][]Microchip{"2": {5}},
+		},
+		{
+			rule:    "bot 0 gives low to output 1 and high to bot 2",
+			bots:    map[string][]Microchip{"2": {5}},
+			outputs: map[string][]Microchip{"1": {2}},
+		},
+		{
+			rule:    "bot 0 gives low to output 1 and high to output 2",
+			bots:    map[string][]Microchip{},
+			outputs: map[string][]Microchip{"1": {2}, "2": {5}},
+		},
+		{
+			rule:    "bot 0 gives low to bot 1 and high to bot 1",
+			bots:    map[string][]Microchip{"1": {2, 5}},
+			outputs: map[string][]Microchip{},
+		},
+		{
+			rule:    "bot 0 gives low to output 1 and high to output 1",
+			bots:    map[string][]Microchip{},
+			outputs: map[string][]Microchip{"1": {2, 5}},
+		},
+		{
+			// Bot 9 already has its hands full, so the chip for output 1
+			// mustn't move either.
+			rule:       "bot 0 gives low to output 1 and high to bot 9",
+			bots:       map[string][]Microchip{"9": {1, 3}},
+			outputs:    map[string][]Microchip{"1": {}},
+			shouldFail: true,
+		},
+		{
+			// Bot 8 has room for one chip but not both.
+			rule:       "bot 0 gives low to bot 8 and high to bot 8",
+			bots:       map[string][]Microchip{"8": {7}},
+			outputs:    map[string][]Microchip{},
+			shouldFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		in := advent.NewInput(context.Background(), "test", strings.NewReader(test.rule))
+		steps, err := ParseInstructions(in)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		bots, outputs := NewBots(), NewOutputs()
+		bots.Find("9").Inventory = []Microchip{1, 3}
+		bots.Find("8").Inventory = []Microchip{7}
+		giver := bots.Find("0")
+		giver.Inventory = []Microchip{2, 5}
+
+		low, high := steps.steps[0].Recipients(bots, outputs)
+		ok := giver.GiveAway(low, high)
+		if ok == test.shouldFail {
+			t.Errorf("%s: expected success to be %v", test.rule, !test.shouldFail)
+		}
+
+		if test.shouldFail {
+			if !reflect.DeepEqual(giver.Inventory, []Microchip{2, 5}) || len(giver.History) > 0 {
+				t.Errorf("%s: the giver shouldn't have changed, got %v", test.rule, giver.Inventory)
+			}
+		} else if len(giver.Inventory) > 0 || !reflect.DeepEqual(giver.History, []History{{2, 5}}) {
+			t.Errorf("%s: the giver should have given both chips, got %v", test.rule, giver.Inventory)
+		}
+
+		for id, chips := range test.bots {
+			if result := bots.Find(id).Inventory; !reflect.DeepEqual(result, chips) {
+				t.Errorf("%s: bot %s assertion error: expected %v, got %v", test.rule, id, chips, result)
+			}
+		}
+		for id, chips := range test.outputs {
+			if result := outputs.Find(id).Inventory; !reflect.DeepEqual(result, chips) {
+				t.Errorf("%s: output %s assertion error: expected %v, got %v", test.rule, id, chips, result)
+			}
+		}
+	}
+}
+
+func TestQuery(t *testing.T) {
+	bots, outputs := runExample(t, &EventEngine{})
+
+	// Bot 2 compares 2 <> 5 and bot 0 compares 3 <> 5.
+	tests := []struct {
+		compare, outputs string
+		part             int
+		expect           interface{}
+		shouldError      bool
+	}{
+		{"5,2", "0", 1, "2", false},
+		{"3,5", "0", 1, "0", false},
+		{"17,61", "0", 1, nil, true},
+		{"2,5", "0,1,2", 2, 30, false},
+		{"2,5", "1,2", 2, 6, false},
+		{"2,5", "0,3", 2, nil, true},
+	}
+
+	for _, test := range tests {
+		query, err := ParseQuery(test.compare, test.outputs, "")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		result, err := query.Answer(test.part, bots, outputs)
+		if test.shouldError {
+			if err == nil {
+				t.Errorf("Expected an error for %+v, got %v", test, result.Answer)
+			}
+			continue
+		} else if err != nil {
+			t.Errorf("Unexpected error for %+v: %s", test, err)
+			continue
+		}
+
+		if result.Answer != test.expect {
+			t.Errorf("Output assertion error for %+v: expected %v, got %v", test, test.expect, result.Answer)
+		}
+	}
+
+	// Chip 5 goes from bot 2 to bot 0.
+	held := []string{}
+	for _, bot := range bots.Held(5) {
+		held = append(held, bot.ID)
+	}
+	if !reflect.DeepEqual(held, []string{"0", "2"}) {
+		t.Errorf("Held assertion error: expected [0 2], got %v", held)
+	}
+
+	// An output that exists but is empty is an error too.
+	outputs.Find("7")
+	if _, err := outputs.Product("7"); err == nil {
+		t.Errorf("Expected an error multiplying an empty output")
+	}
+}
+
+func TestGraph(t *testing.T) {
+	// Bot 3 gets chips but has no rule, and bot 4 never gets any.
+	const rules = `value 1 goes to bot 0
+value 2 goes to bot 0
+bot 0 gives low to bot 3 and high to output 0
+bot 4 gives low to output 1 and high to output 2`
+
+	in := advent.NewInput(context.Background(), "test", strings.NewReader(rules))
+	steps, err := ParseInstructions(in)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	bots := NewBots()
+	if err := (&EventEngine{}).Run(context.Background(), steps, bots, NewOutputs()); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	var buf strings.Builder
+	if err := NewGraph(steps, bots).WriteDOT(&buf); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	dot := buf.String()
+
+	for _, line := range []string{
+		`bot3 [label="Bot 3", shape=ellipse, color=red];`,
+		`bot0 -> bot3 [label="low: 1"];`,
+		`bot0 -> output0 [label="high: 2"];`,
+		`bot4 -> output1 [label="low", style=dashed];`,
+	} {
+		if !strings.Contains(dot, line) {
+			t.Errorf("Expected the DOT output to contain %q, got:\n%s", line, dot)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	const rules = `value 1 goes to bot 0
+value 2 goes to bot 0
+bot 0 gives low to bot 1 and high to bot 2
+bot 1 gives low to bot 2 and high to output 0
+bot 2 gives low to bot 1 and high to output 1
+bot 0 gives low to output 3 and high to output 3
+value 5 goes to bot 2
+bot 7 gives low to bot 9 and high to output 4`
+
+	in := advent.NewInput(context.Background(), "test", strings.NewReader(rules))
+	steps, err := ParseInstructions(in)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	expected := []string{
+		":4: Bots 1 -> 2 -> 1 give chips to each other in a cycle",
+		":6: Bot 0 already has a give rule on line 3",
+		":7: Bot 2 would be given more than two chips",
+		":8: Bot 7 is only given 0 chip(s), so it never gives any away",
+		":8: Bot 9 is given chips but has no give rule",
+		":8: Output 4 can never be given a chip",
+	}
+	result := []string{}
+	for _, problem := range Validate(steps) {
+		result = append(result, problem.Error())
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Output assertion error: expected\n%s\ngot\n%s", strings.Join(expected, "\n"), strings.Join(result, "\n"))
+	}
+
+	// The example from the puzzle is fine.
+	in = advent.NewInput(context.Background(), "example", strings.NewReader(example))
+	if steps, err = ParseInstructions(in); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if problems := Validate(steps); len(problems) > 0 {
+		t.Errorf("Expected no problems with the example, got %v", problems)
+	}
+
+	// Without strict mode the factory runs in spite of the problems, except
+	// for a bot with more than one give rule.
+	solver := &Solver{}
+	in = advent.NewInput(context.Background(), "warning", strings.NewReader(example+"\nvalue 9 goes to bot 5"))
+	if factory, err := solver.Run(in); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	} else if len(factory.Problems) != 1 {
+		t.Errorf("Expected one problem, got %v", factory.Problems)
+	}
+
+	in = advent.NewInput(context.Background(), "duplicate", strings.NewReader(example+"\nbot 2 gives low to output 3 and high to output 4"))
+	if _, err := solver.Run(in); !IsDuplicateRule(err) {
+		t.Errorf("Expected a duplicate rule error, got %v", err)
+	}
+}
@@ -0,0 +1,150 @@
+package day10
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Type Engine runs the factory's instructions until there's nothing left to
+// do, moving the chips between the bots and outputs.
+type Engine interface {
+	Run(ctx context.Context, steps *Steps, bots *Bots, outputs *Outputs) error
+}
+
+// Engines are the simulation engines that can be picked from the command line,
+// by name.
+var Engines = map[string]func(delay time.Duration) Engine{
+	"concurrent": func(delay time.Duration) Engine {
+		return &ConcurrentEngine{Delay: delay}
+	},
+	"event": func(delay time.Duration) Engine {
+		return &EventEngine{Delay: delay}
+	},
+	"tick": func(delay time.Duration) Engine {
+		return &TickEngine{Delay: delay}
+	},
+}
+
+// EngineNames returns the sorted names of the engines.
+func EngineNames() []string {
+	names := []string{}
+	for name := range Engines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// wait pauses for a delay, unless the context is cancelled first.
+func wait(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return ctx.Err()
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(delay):
+		return nil
+	}
+}
+
+// Type TickEngine runs the instructions in ticks: each tick tries every step
+// that hasn't been done yet, and it stops after a tick where nothing could be
+// done.
+type TickEngine struct {
+	Delay time.Duration // Pause between ticks, for watching it play out
+}
+
+// Run runs the instructions.
+func (e *TickEngine) Run(ctx context.Context, steps *Steps, bots *Bots, outputs *Outputs) error {
+	for tick := 0; ; tick++ {
+		if err := wait(ctx, e.Delay); err != nil {
+			return err
+		}
+		logger.Debug("### TICK %d ###", tick)
+
+		if DoOneLoop(steps, bots, outputs) {
+			return nil
+		}
+	}
+}
+
+// Type EventEngine runs the instructions as events: the moment a bot has two
+// chips in its hands it's put on a queue, and the bots on the queue follow
+// their give rule in turn. Each step is only looked at once.
+type EventEngine struct {
+	Delay time.Duration // Pause before each bot gives away its chips
+}
+
+// Run runs the instructions.
+func (e *EventEngine) Run(ctx context.Context, steps *Steps, bots *Bots, outputs *Outputs) error {
+	// Index the give rules by bot, and hold on to the inputs.
+	rules := map[string]*Step{}
+	inputs := []*Step{}
+	for _, step := range steps.steps {
+		if step.Action == InputAction {
+			inputs = append(inputs, step)
+		} else if _, ok := rules[step.BotID]; ok {
+			return fmt.Errorf("Bot %s has more than one give rule", step.BotID)
+		} else {
+			rules[step.BotID] = step
+		}
+	}
+
+	// The bots that have two chips and are ready to give them away.
+	queue := []*Bot{}
+
+	for _, input := range inputs {
+		bot := bots.Find(input.BotID)
+		if !bot.Give(input.Value) {
+			return fmt.Errorf("Bot %s can't take chip %d: its hands are full", bot.ID, input.Value)
+		}
+		if bot.Full() {
+			queue = append(queue, bot)
+		}
+		botLog.Trace("[ OK ] Input gave %d to Bot %s", input.Value, input.BotID)
+		input.Done = true
+
+		// Let every bot that's ready do its thing before the next input.
+		for len(queue) > 0 {
+			if err := wait(ctx, e.Delay); err != nil {
+				return err
+			}
+
+			bot := queue[0]
+			queue = queue[1:]
+
+			rule, ok := rules[bot.ID]
+			if !ok {
+				logger.Debug("Bot %s has two chips but no give rule", bot.ID)
+				continue
+			}
+
+			// The inventory is already sorted.
+			lower, higher := bot.Inventory[0], bot.Inventory[1]
+			low, high := rule.Recipients(bots, outputs)
+			if !bot.GiveAway(low, high) {
+				return fmt.Errorf("Bot %s can't give L%d to %s and H%d to %s: no room", bot.ID, lower, low, higher, high)
+			}
+			botLog.Trace("[ OK ] Bot %s gave L%d to %s and H%d to %s", bot.ID, lower, low, higher, high)
+
+			// Queue up the bots whose hands are full now. A bot that was given
+			// both chips only goes on the queue once.
+			recipients := []Recipient{low}
+			if high != low {
+				recipients = append(recipients, high)
+			}
+			for _, r := range recipients {
+				if recipient, ok := r.(*Bot); ok && recipient.Full() {
+					queue = append(queue, recipient)
+				}
+			}
+			rule.Done = true
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,204 @@
+package day10
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/kirsle/goadvent2016/advent"
+)
+
+// Type Graph is the bot network as a directed graph: inputs point to the bot
+// they give a chip to, and bots point to whoever gets their low and high chips.
+type Graph struct {
+	Nodes []Node
+	Edges []Edge
+}
+
+// Type Node is an input, bot or output in the Graph.
+type Node struct {
+	ID      string // Unique ID, like "bot2"
+	Label   string // Like "Bot 2"
+	Kind    string // "input", "bot" or "output"
+	DeadEnd bool   // A bot that got chips but has no give rule
+}
+
+// Type Edge is a route that chips can take in the Graph.
+type Edge struct {
+	From  string
+	To    string
+	Label string      // "low", "high" or "" for an input
+	Chips []Microchip // The chips that actually went this way
+}
+
+// NewGraph builds the graph of a factory after it has been run, so each edge
+// knows which chips went along it.
+func NewGraph(steps *Steps, bots *Bots) *Graph {
+	g := &Graph{}
+	seen := map[string]bool{}
+	node := func(n Node) string {
+		if !seen[n.ID] {
+			seen[n.ID] = true
+			g.Nodes = append(g.Nodes, n)
+		}
+		return n.ID
+	}
+	botNode := func(id string) string {
+		return node(Node{ID: "bot" + id, Label: "Bot " + id, Kind: "bot"})
+	}
+	recipientNode := func(toBot bool, id string) string {
+		if toBot == ToBot {
+			return botNode(id)
+		}
+		return node(Node{ID: "output" + id, Label: "Output " + id, Kind: "output"})
+	}
+
+	hasRule := map[string]bool{}
+	for i, step := range steps.steps {
+		if step.Action == InputAction {
+			from := node(Node{
+				ID:    fmt.Sprintf("input%d", i),
+				Label: fmt.Sprintf("Value %d", step.Value),
+				Kind:  "input",
+			})
+			g.Edges = append(g.Edges, Edge{
+				From:  from,
+				To:    botNode(step.BotID),
+				Chips: []Microchip{step.Value},
+			})
+			continue
+		}
+
+		// The bot's history says which chips it gave away with this rule.
+		hasRule[step.BotID] = true
+		from := botNode(step.BotID)
+		low := Edge{From: from, To: recipientNode(step.LowTo, step.LowID), Label: "low"}
+		high := Edge{From: from, To: recipientNode(step.HighTo, step.HighID), Label: "high"}
+		if bot, ok := bots.Get(step.BotID); ok {
+			for _, h := range bot.History {
+				low.Chips = append(low.Chips, h.A)
+				high.Chips = append(high.Chips, h.B)
+			}
+		}
+		g.Edges = append(g.Edges, low, high)
+	}
+
+	// Bots that were handed chips but can't pass them on are dead ends.
+	for i, n := range g.Nodes {
+		id := strings.TrimPrefix(n.ID, "bot")
+		if n.Kind == "bot" && !hasRule[id] {
+			g.Nodes[i].DeadEnd = true
+		}
+	}
+
+	return g
+}
+
+// label describes the chips that went along an edge, like "low: 2, 5".
+func (e Edge) label() string {
+	chips := []string{}
+	for _, chip := range e.Chips {
+		chips = append(chips, fmt.Sprint(chip))
+	}
+
+	if e.Label == "" {
+		return strings.Join(chips, ", ")
+	} else if len(chips) == 0 {
+		return e.Label
+	}
+	return e.Label + ": " + strings.Join(chips, ", ")
+}
+
+// WriteDOT writes the graph in the Graphviz DOT language. Dead-end bots are
+// red, and routes that no chip took are dashed.
+func (g *Graph) WriteDOT(w io.Writer) error {
+	shapes := map[string]string{
+		"input":  "plaintext",
+		"bot":    "ellipse",
+		"output": "box",
+	}
+
+	lines := []string{"digraph factory {", "\trankdir=LR;"}
+	for _, n := range g.Nodes {
+		attrs := fmt.Sprintf("label=%q, shape=%s", n.Label, shapes[n.Kind])
+		if n.DeadEnd {
+			attrs += ", color=red"
+		}
+		lines = append(lines, fmt.Sprintf("\t%s [%s];", n.ID, attrs))
+	}
+	for _, e := range g.Edges {
+		attrs := fmt.Sprintf("label=%q", e.label())
+		if len(e.Chips) == 0 {
+			attrs += ", style=dashed"
+		}
+		lines = append(lines, fmt.Sprintf("\t%s -> %s [%s];", e.From, e.To, attrs))
+	}
+	lines = append(lines, "}")
+
+	_, err := fmt.Fprintln(w, strings.Join(lines, "\n"))
+	return err
+}
+
+// WriteMermaid writes the graph as a Mermaid flowchart. Dead-end bots are
+// red, and routes that no chip took are dotted.
+func (g *Graph) WriteMermaid(w io.Writer) error {
+	shapes := map[string]string{
+		"input":  "%s>%q]",
+		"bot":    "%s([%q])",
+		"output": "%s[(%q)]",
+	}
+
+	lines := []string{"flowchart LR"}
+	deadEnds := []string{}
+	for _, n := range g.Nodes {
+		lines = append(lines, "    "+fmt.Sprintf(shapes[n.Kind], n.ID, n.Label))
+		if n.DeadEnd {
+			deadEnds = append(deadEnds, n.ID)
+		}
+	}
+	for _, e := range g.Edges {
+		arrow := "-->"
+		if len(e.Chips) == 0 {
+			arrow = "-.->"
+		}
+		if label := e.label(); label != "" {
+			arrow += "|" + label + "|"
+		}
+		lines = append(lines, fmt.Sprintf("    %s %s %s", e.From, arrow, e.To))
+	}
+	if len(deadEnds) > 0 {
+		lines = append(lines, "    classDef deadEnd stroke:#f00,color:#f00")
+		lines = append(lines, "    class "+strings.Join(deadEnds, ",")+" deadEnd")
+	}
+
+	_, err := fmt.Fprintln(w, strings.Join(lines, "\n"))
+	return err
+}
+
+// ExportFormats lists the formats day 10 can be exported to.
+func (s *Solver) ExportFormats() []string {
+	return []string{"dot", "mermaid"}
+}
+
+// Export runs the factory and draws the bot network as a graph.
+func (s *Solver) Export(w io.Writer, format string, in *advent.Input) error {
+	factory, err := s.Run(in)
+	if err != nil {
+		return err
+	}
+
+	// There's no result to add the problems to, so log them instead.
+	for _, problem := range factory.Problems {
+		logger.Info("%s", problem)
+	}
+
+	g := NewGraph(factory.Steps, factory.Bots)
+	switch format {
+	case "dot":
+		return g.WriteDOT(w)
+	case "mermaid":
+		return g.WriteMermaid(w)
+	}
+	return errors.New("Unknown export format: " + format)
+}
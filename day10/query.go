@@ -0,0 +1,205 @@
+package day10
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kirsle/goadvent2016/advent"
+)
+
+// Get finds a bot without creating it.
+func (s *Bots) Get(id string) (*Bot, bool) {
+	bot, ok := s.bots[id]
+	return bot, ok
+}
+
+// List returns all the bots, sorted by their number.
+func (s *Bots) List() []*Bot {
+	result := []*Bot{}
+	for _, bot := range s.bots {
+		result = append(result, bot)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return lessID(result[i].ID, result[j].ID)
+	})
+	return result
+}
+
+// Get finds an output without creating it.
+func (s *Outputs) Get(id string) (*Output, bool) {
+	output, ok := s.outputs[id]
+	return output, ok
+}
+
+// List returns all the outputs, sorted by their number.
+func (s *Outputs) List() []*Output {
+	result := []*Output{}
+	for _, output := range s.outputs {
+		result = append(result, output)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return lessID(result[i].ID, result[j].ID)
+	})
+	return result
+}
+
+// lessID sorts IDs by their number, or as strings if they aren't numbers.
+func lessID(a, b string) bool {
+	x, errX := strconv.Atoi(a)
+	y, errY := strconv.Atoi(b)
+	if errX != nil || errY != nil {
+		return a < b
+	}
+	return x < y
+}
+
+// Compared finds the bots that compared two chips, in either order.
+func (s *Bots) Compared(a, b Microchip) []*Bot {
+	if a > b {
+		a, b = b, a
+	}
+
+	result := []*Bot{}
+	for _, bot := range s.List() {
+		for _, h := range bot.History {
+			if h.A == a && h.B == b {
+				result = append(result, bot)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// Held finds the bots that ever held a chip, whether they still have it or
+// handed it on.
+func (s *Bots) Held(chip Microchip) []*Bot {
+	result := []*Bot{}
+	for _, bot := range s.List() {
+		held := false
+		for _, current := range bot.Inventory {
+			held = held || current == chip
+		}
+		for _, h := range bot.History {
+			held = held || h.A == chip || h.B == chip
+		}
+		if held {
+			result = append(result, bot)
+		}
+	}
+	return result
+}
+
+// Product multiplies the chips in the outputs. It's an error for an output not
+// to exist or to hold anything other than one chip.
+func (s *Outputs) Product(ids ...string) (int, error) {
+	product := 1
+	for _, id := range ids {
+		output, ok := s.Get(id)
+		if !ok {
+			return 0, fmt.Errorf("Output %s doesn't exist", id)
+		}
+
+		switch len(output.Inventory) {
+		case 0:
+			return 0, fmt.Errorf("Output %s is empty", id)
+		case 1:
+			product *= int(output.Inventory[0])
+		default:
+			return 0, fmt.Errorf("Output %s holds %d chips: %v", id, len(output.Inventory), output.Inventory)
+		}
+	}
+	return product, nil
+}
+
+// ParseChips parses a list of chip values like "17,61".
+func ParseChips(list string) ([]Microchip, error) {
+	values, err := advent.StringsToInts(splitList(list))
+	if err != nil {
+		return nil, fmt.Errorf("Invalid list of chips %q: %s", list, err)
+	}
+
+	result := []Microchip{}
+	for _, value := range values {
+		result = append(result, Microchip(value))
+	}
+	return result, nil
+}
+
+// splitList splits a comma separated list, ignoring spaces.
+func splitList(list string) []string {
+	result := []string{}
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// Type Query is a question to ask about the factory once it's finished.
+type Query struct {
+	Compare []Microchip // Which bot compared these two chips? (part 1)
+	Outputs []string    // What's the product of these outputs? (part 2)
+	Held    []Microchip // Which bots held these chips?
+}
+
+// ParseQuery builds a Query from the command line options.
+func ParseQuery(compare, outputs, held string) (*Query, error) {
+	q := &Query{
+		Outputs: splitList(outputs),
+	}
+
+	var err error
+	if q.Compare, err = ParseChips(compare); err != nil {
+		return nil, err
+	}
+	if len(q.Compare) != 2 {
+		return nil, fmt.Errorf("Need two chips to find a comparison, like 17,61; got %q", compare)
+	}
+	if len(q.Outputs) == 0 {
+		return nil, errors.New("Need at least one output to multiply, like 0,1,2")
+	}
+	if q.Held, err = ParseChips(held); err != nil {
+		return nil, err
+	}
+
+	return q, nil
+}
+
+// Answer answers the query for a part of the puzzle. Part 1 finds the bot that
+// compared two chips and part 2 multiplies the outputs. The bots that held
+// chips are listed as diagnostics.
+func (q *Query) Answer(part int, bots *Bots, outputs *Outputs) (advent.Result, error) {
+	var result advent.Result
+
+	if part == 1 {
+		found := bots.Compared(q.Compare[0], q.Compare[1])
+		if len(found) == 0 {
+			return result, fmt.Errorf("No bot compared %d <> %d", q.Compare[0], q.Compare[1])
+		}
+		result.Answer = found[0].ID
+		for _, bot := range found[1:] {
+			result.Diagnose("%s also compared %d <> %d", bot, q.Compare[0], q.Compare[1])
+		}
+	} else {
+		product, err := outputs.Product(q.Outputs...)
+		if err != nil {
+			return result, err
+		}
+		result.Answer = product
+	}
+
+	for _, chip := range q.Held {
+		ids := []string{}
+		for _, bot := range bots.Held(chip) {
+			ids = append(ids, bot.ID)
+		}
+		result.Diagnose("Chip %d was held by bots: %s", chip, strings.Join(ids, ", "))
+	}
+
+	return result, nil
+}
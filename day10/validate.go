@@ -0,0 +1,203 @@
+package day10
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kirsle/goadvent2016/advent"
+)
+
+// Type DuplicateRuleError is the problem of a bot with more than one give rule.
+// The engines can't know which rule to follow, so unlike Validate's other
+// problems it's always an error.
+type DuplicateRuleError struct {
+	BotID     string
+	FirstLine int // Where the bot's first give rule is
+}
+
+// Error formats the error like "Bot 1 already has a give rule on line 3".
+func (e *DuplicateRuleError) Error() string {
+	return fmt.Sprintf("Bot %s already has a give rule on line %d", e.BotID, e.FirstLine)
+}
+
+// IsDuplicateRule tells whether a problem is a DuplicateRuleError.
+func IsDuplicateRule(err error) bool {
+	var dup *DuplicateRuleError
+	return errors.As(err, &dup)
+}
+
+// Validate looks for problems with the instructions without running them:
+//
+//   - a bot with more than one give rule
+//   - a bot that's given chips but has no give rule of its own
+//   - a bot that would be given more than two chips
+//   - a bot with a give rule that will never have two chips to give
+//   - bots that give chips to each other in a cycle, so each waits on the other
+//   - an output that no chip can ever reach
+//
+// The problems are sorted by line number. Their File is left blank for the
+// caller to fill in.
+func Validate(steps *Steps) []*advent.ParseError {
+	problems := []*advent.ParseError{}
+	report := func(line int, tmpl string, a ...interface{}) {
+		problems = append(problems, &advent.ParseError{
+			Line: line,
+			Err:  fmt.Errorf(tmpl, a...),
+		})
+	}
+
+	// Index the rules, and where each bot's chips come from.
+	var (
+		rules    = map[string]*Step{}
+		incoming = map[string][]*Step{} // Steps that give a chip to each bot
+		outputs  = map[string][]*Step{} // Steps that give a chip to each output
+	)
+	for _, step := range steps.steps {
+		if step.Action == InputAction {
+			incoming[step.BotID] = append(incoming[step.BotID], step)
+			continue
+		}
+
+		if first, ok := rules[step.BotID]; ok {
+			problems = append(problems, &advent.ParseError{
+				Line: step.Line,
+				Err:  &DuplicateRuleError{step.BotID, first.Line},
+			})
+			continue
+		}
+		rules[step.BotID] = step
+
+		for _, to := range step.Targets() {
+			if to.ToBot == ToBot {
+				incoming[to.ID] = append(incoming[to.ID], step)
+			} else {
+				outputs[to.ID] = append(outputs[to.ID], step)
+			}
+		}
+	}
+
+	// Check how many chips each bot is given.
+	for id, from := range incoming {
+		if _, ok := rules[id]; !ok {
+			report(from[0].Line, "Bot %s is given chips but has no give rule", id)
+		}
+		if len(from) > 2 {
+			report(from[2].Line, "Bot %s would be given more than two chips", id)
+		}
+	}
+	for id, rule := range rules {
+		if n := len(incoming[id]); n < 2 {
+			report(rule.Line, "Bot %s is only given %d chip(s), so it never gives any away", id, n)
+		}
+	}
+
+	// Look for cycles.
+	for _, cycle := range findCycles(rules) {
+		report(rules[cycle[0]].Line, "Bots %s give chips to each other in a cycle", strings.Join(cycle, " -> "))
+	}
+
+	// Work out which bots will get to give away their chips: the ones that are
+	// given two chips by inputs or by other bots that give theirs away.
+	fires := map[string]bool{}
+	for changed := true; changed; {
+		changed = false
+		for id, rule := range rules {
+			if fires[id] {
+				continue
+			}
+
+			var chips int
+			for _, from := range incoming[id] {
+				if from.Action == InputAction || fires[from.BotID] {
+					chips++
+				}
+			}
+			if chips >= 2 {
+				fires[rule.BotID] = true
+				changed = true
+			}
+		}
+	}
+
+	for id, from := range outputs {
+		reachable := false
+		for _, step := range from {
+			reachable = reachable || fires[step.BotID]
+		}
+		if !reachable {
+			report(from[0].Line, "Output %s can never be given a chip", id)
+		}
+	}
+
+	sort.Slice(problems, func(i, j int) bool {
+		if problems[i].Line == problems[j].Line {
+			return problems[i].Err.Error() < problems[j].Err.Error()
+		}
+		return problems[i].Line < problems[j].Line
+	})
+	return problems
+}
+
+// findCycles finds the cycles of bots that give chips to each other, like
+// [1 2 1]. Each cycle is only reported once.
+func findCycles(rules map[string]*Step) [][]string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	var (
+		cycles = [][]string{}
+		state  = map[string]int{}
+		path   = []string{}
+		visit  func(id string)
+	)
+	visit = func(id string) {
+		state[id] = visiting
+		path = append(path, id)
+
+		if rule, ok := rules[id]; ok {
+			for _, to := range rule.Targets() {
+				if to.ToBot != ToBot {
+					continue
+				}
+
+				switch state[to.ID] {
+				case unvisited:
+					visit(to.ID)
+				case visiting:
+					// Found a way back to a bot on the current path.
+					for i, p := range path {
+						if p == to.ID {
+							cycle := append([]string{}, path[i:]...)
+							cycles = append(cycles, append(cycle, to.ID))
+							break
+						}
+					}
+				}
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[id] = visited
+	}
+
+	// Visit the bots in a fixed order so the results are the same each time.
+	ids := []string{}
+	for id := range rules {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return lessID(ids[i], ids[j])
+	})
+	for _, id := range ids {
+		if state[id] == unvisited {
+			visit(id)
+		}
+	}
+
+	return cycles
+}